@@ -0,0 +1,118 @@
+package game
+
+// denominations lists the army pieces that make up a territory's strength, from smallest to largest
+var denominations = []Army{Infantry, Cavalry, Artillery}
+
+// Strength returns the total value of the armies on the territory
+// For example, one Cavalry and two Infantry have a strength of 7
+func (t *Territory) Strength() int {
+	total := 0
+	for _, army := range denominations {
+		total += int(army) * t.Armies[army]
+	}
+	return total
+}
+
+// AddArmies adds n armies to the territory as Infantry
+func (t *Territory) AddArmies(n int) {
+	t.Armies[Infantry] += n
+}
+
+// RemoveArmies removes n armies worth of pieces from the territory
+// Pieces are removed without breaking any of them when possible, using up the smallest pieces first
+// Otherwise a single larger piece is broken into change, e.g. a Cavalry into five Infantry
+func (t *Territory) RemoveArmies(n int) error {
+	_, err := t.removeArmies(n)
+	return err
+}
+
+// removeArmies removes n armies worth of pieces from the territory and returns the number of pieces it had to break
+func (t *Territory) removeArmies(n int) (int, error) {
+	if n < 0 || n > t.Strength() {
+		return 0, &InsufficientArmiesError{Territory: t.Name, Armies: t.Strength(), Needed: n}
+	}
+
+	broken := 0
+	for {
+		if payment, ok := exactPayment(t.Armies, n); ok {
+			for army, count := range payment {
+				t.Armies[army] -= count
+			}
+			return broken, nil
+		}
+
+		// Break the smallest piece that lets us pay exactly, falling back on the smallest piece we have
+		// Since the territory has enough strength, paying is always possible once everything is broken into Infantry
+		var piece Army
+		for _, army := range denominations[1:] {
+			if t.Armies[army] == 0 {
+				continue
+			}
+			if piece == 0 {
+				piece = army
+			}
+			armies := breakPiece(copyArmies(t.Armies), army)
+			if _, ok := exactPayment(armies, n); ok {
+				piece = army
+				break
+			}
+		}
+		t.Armies = breakPiece(t.Armies, piece)
+		broken++
+	}
+}
+
+// exactPayment looks for pieces adding up to exactly n, without breaking any of them
+// It prefers paying with the smallest pieces, keeping the larger pieces on the board
+func exactPayment(armies map[Army]int, n int) (map[Army]int, bool) {
+	for artillery := 0; artillery <= armies[Artillery] && artillery*int(Artillery) <= n; artillery++ {
+		for cavalry := 0; cavalry <= armies[Cavalry] && artillery*int(Artillery)+cavalry*int(Cavalry) <= n; cavalry++ {
+			infantry := n - artillery*int(Artillery) - cavalry*int(Cavalry)
+			if infantry <= armies[Infantry] {
+				return map[Army]int{Infantry: infantry, Cavalry: cavalry, Artillery: artillery}, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// breakPiece breaks one piece of the given army type into the next smaller denominations
+// A Cavalry becomes five Infantry and an Artillery becomes one Cavalry and five Infantry
+func breakPiece(armies map[Army]int, piece Army) map[Army]int {
+	armies[piece]--
+	switch piece {
+	case Cavalry:
+		armies[Infantry] += 5
+	case Artillery:
+		armies[Cavalry]++
+		armies[Infantry] += 5
+	}
+	return armies
+}
+
+func copyArmies(armies map[Army]int) map[Army]int {
+	c := make(map[Army]int)
+	for army, count := range armies {
+		c[army] = count
+	}
+	return c
+}
+
+// Consolidate exchanges the pieces on the territory for as few pieces as possible with the same strength
+func (t *Territory) Consolidate() {
+	strength := t.Strength()
+	t.Armies[Artillery] = strength / int(Artillery)
+	strength %= int(Artillery)
+	t.Armies[Cavalry] = strength / int(Cavalry)
+	strength %= int(Cavalry)
+	t.Armies[Infantry] = strength
+}
+
+// moveArmies moves n armies from the territory to another territory
+func (t *Territory) moveArmies(to *Territory, n int) error {
+	if err := t.RemoveArmies(n); err != nil {
+		return err
+	}
+	to.AddArmies(n)
+	return nil
+}
@@ -0,0 +1,120 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func newTestTerritory(infantry, cavalry, artillery int) *Territory {
+	return &Territory{
+		Name:   "Test Territory",
+		Armies: map[Army]int{Infantry: infantry, Cavalry: cavalry, Artillery: artillery},
+	}
+}
+
+func TestStrength(t *testing.T) {
+	testCases := []struct {
+		infantry  int
+		cavalry   int
+		artillery int
+		strength  int
+	}{
+		{infantry: 0, cavalry: 0, artillery: 0, strength: 0},
+		{infantry: 3, cavalry: 0, artillery: 0, strength: 3},
+		{infantry: 0, cavalry: 2, artillery: 0, strength: 10},
+		{infantry: 0, cavalry: 0, artillery: 4, strength: 40},
+		{infantry: 2, cavalry: 1, artillery: 3, strength: 37},
+	}
+
+	for _, testCase := range testCases {
+		territory := newTestTerritory(testCase.infantry, testCase.cavalry, testCase.artillery)
+		if territory.Strength() != testCase.strength {
+			t.Errorf("Expected %v to have strength %d, got: %d", territory.Armies, testCase.strength, territory.Strength())
+		}
+	}
+
+	territory := newTestTerritory(0, 1, 0)
+	territory.AddArmies(3)
+	if territory.Armies[Infantry] != 3 || territory.Strength() != 8 {
+		t.Errorf("Expected adding three armies to add three Infantry, got: %v", territory.Armies)
+	}
+}
+
+func TestRemoveArmies(t *testing.T) {
+	// Check every mix of up to six pieces of each type, removing every possible number of armies
+	for infantry := 0; infantry <= 6; infantry++ {
+		for cavalry := 0; cavalry <= 6; cavalry++ {
+			for artillery := 0; artillery <= 6; artillery++ {
+				strength := newTestTerritory(infantry, cavalry, artillery).Strength()
+				for n := 0; n <= strength; n++ {
+					name := fmt.Sprintf("%dI%dC%dA-%d", infantry, cavalry, artillery, n)
+					territory := newTestTerritory(infantry, cavalry, artillery)
+					_, exact := exactPayment(copyArmies(territory.Armies), n)
+
+					broken, err := territory.removeArmies(n)
+					if err != nil {
+						t.Fatalf("%s: Unexpected error removing armies: %s", name, err)
+					}
+					if territory.Strength() != strength-n {
+						t.Errorf("%s: Expected strength %d, got: %d", name, strength-n, territory.Strength())
+					}
+					for army, count := range territory.Armies {
+						if count < 0 {
+							t.Errorf("%s: Negative number of %s: %d", name, army, count)
+						}
+					}
+					if exact && broken != 0 {
+						t.Errorf("%s: Broke %d pieces when the armies could be removed exactly", name, broken)
+					}
+					if !exact && broken != 1 {
+						t.Errorf("%s: Expected to break exactly one piece, broke: %d", name, broken)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestRemoveArmiesPrefersSmallPieces(t *testing.T) {
+	territory := newTestTerritory(5, 1, 1)
+	if err := territory.RemoveArmies(5); err != nil {
+		t.Fatal("Unexpected error removing armies:", err)
+	}
+	if territory.Armies[Infantry] != 0 || territory.Armies[Cavalry] != 1 || territory.Armies[Artillery] != 1 {
+		t.Errorf("Expected five Infantry to be removed before the Cavalry, got: %v", territory.Armies)
+	}
+
+	// Losing one army from a lone Cavalry breaks it into five Infantry
+	territory = newTestTerritory(0, 1, 0)
+	if err := territory.RemoveArmies(1); err != nil {
+		t.Fatal("Unexpected error removing armies:", err)
+	}
+	if territory.Armies[Infantry] != 4 || territory.Armies[Cavalry] != 0 {
+		t.Errorf("Expected a Cavalry to be broken into Infantry, got: %v", territory.Armies)
+	}
+
+	var insufficient *InsufficientArmiesError
+	if err := territory.RemoveArmies(5); !errors.As(err, &insufficient) {
+		t.Errorf("Expected an InsufficientArmiesError removing more armies than the territory has, got: %v", err)
+	}
+}
+
+func TestConsolidate(t *testing.T) {
+	for infantry := 0; infantry <= 12; infantry++ {
+		for cavalry := 0; cavalry <= 4; cavalry++ {
+			for artillery := 0; artillery <= 2; artillery++ {
+				territory := newTestTerritory(infantry, cavalry, artillery)
+				strength := territory.Strength()
+				territory.Consolidate()
+
+				if territory.Strength() != strength {
+					t.Errorf("Consolidating %dI%dC%dA changed its strength from %d to %d", infantry, cavalry, artillery, strength, territory.Strength())
+				}
+				if territory.Armies[Infantry] >= 5 || territory.Armies[Cavalry] >= 2 {
+					t.Errorf("Consolidating %dI%dC%dA left pieces that could be exchanged: %v", infantry, cavalry, artillery, territory.Armies)
+				}
+			}
+		}
+	}
+}
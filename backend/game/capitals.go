@@ -0,0 +1,66 @@
+package game
+
+// CapitalPhase is the phase of a capital risk game in which the players take turns designating their capitals
+// It follows the initial placement of armies
+const CapitalPhase Phase = "capital"
+
+// DesignateCapital makes one of the player's territories their capital
+// Once every player has designated a capital the first turn begins
+func (g *Game) DesignateCapital(playerID int, territory string) error {
+	if err := g.checkTurn(playerID, CapitalPhase); err != nil {
+		return err
+	}
+	t, ok := g.Territories[territory]
+	if !ok {
+		return &UnknownTerritoryError{Name: territory}
+	}
+	if !t.isOwnedBy(playerID) {
+		return &TerritoryNotOwnedError{Territory: territory, PlayerID: playerID}
+	}
+
+	g.record(&CapitalDesignated{PlayerID: playerID, Territory: territory})
+	if g.Capitals == nil {
+		g.Capitals = make(map[int]string)
+	}
+	g.Capitals[playerID] = territory
+
+	if len(g.Capitals) == len(g.Players) {
+		g.beginTurns()
+		return nil
+	}
+	g.CurrentPlayer++
+	return nil
+}
+
+// CapitalOf returns the player whose capital the territory is, or nil if it isn't a capital
+func (g *Game) CapitalOf(territory string) *Player {
+	for playerID, capital := range g.Capitals {
+		if capital == territory {
+			return &g.Players[playerID]
+		}
+	}
+	return nil
+}
+
+// capitalWinners returns the player holding every capital, once all of the capitals have been designated
+// In a team game the capitals may be held between teammates
+func (g *Game) capitalWinners() []int {
+	if len(g.Capitals) < len(g.Players) || g.PendingConquest != nil {
+		return nil
+	}
+
+	var holder *Player
+	for _, capital := range g.Capitals {
+		owner := g.Territories[capital].OwnedBy
+		if owner == nil {
+			return nil
+		}
+		if holder == nil {
+			holder = owner
+		}
+		if !g.isAllied(g.Territories[capital], holder.ID) {
+			return nil
+		}
+	}
+	return []int{holder.ID}
+}
@@ -0,0 +1,71 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDesignateCapital(t *testing.T) {
+	game := newTestGame(t, Options{Mode: CapitalRisk})
+	placeAllArmies(t, game)
+	if game.Phase != CapitalPhase || game.CurrentPlayer != 0 {
+		t.Fatalf("Expected player 0 to designate a capital once all armies are placed. Got: player %d, phase %q", game.CurrentPlayer, game.Phase)
+	}
+
+	owned := make(map[int]string)
+	for name, territory := range game.Territories {
+		owned[territory.OwnedBy.ID] = name
+	}
+
+	var notOwned *TerritoryNotOwnedError
+	if err := game.DesignateCapital(0, owned[1]); !errors.As(err, &notOwned) {
+		t.Errorf("Expected a TerritoryNotOwnedError designating another player's territory, got: %v", err)
+	}
+	var notYourTurn *NotYourTurnError
+	if err := game.DesignateCapital(1, owned[1]); !errors.As(err, &notYourTurn) {
+		t.Errorf("Expected a NotYourTurnError when player 1 designates first, got: %v", err)
+	}
+
+	for _, p := range game.Players {
+		if err := game.DesignateCapital(p.ID, owned[p.ID]); err != nil {
+			t.Fatalf("Unexpected error designating player %d's capital: %s", p.ID, err)
+		}
+	}
+	if game.Phase != ReinforcePhase || game.CurrentPlayer != 0 || game.TurnNumber != 1 {
+		t.Errorf("Expected turn 1 to start once every capital is designated. Got: turn %d, player %d, phase %q", game.TurnNumber, game.CurrentPlayer, game.Phase)
+	}
+	if capitalOf := game.CapitalOf(owned[2]); capitalOf == nil || capitalOf.ID != 2 {
+		t.Errorf("Expected %q to be player 2's capital, got: %v", owned[2], capitalOf)
+	}
+	if len(game.Winners) != 0 {
+		t.Errorf("Expected nobody to win by designating capitals, got: %v", game.Winners)
+	}
+}
+
+func TestCapitalVictory(t *testing.T) {
+	game := newAttackGame(t, 6, 1, 6, 1)
+	game.Options.Mode = CapitalRisk
+	game.Territories["Alaska"].Armies[Infantry] = 5
+	game.Territories["Kamchatka"].OwnedBy = &game.Players[2]
+	game.Capitals = map[int]string{0: "Alaska", 1: "Alberta", 2: "Kamchatka"}
+
+	if _, err := game.Attack(0, "Alaska", "Alberta", 1); err != nil {
+		t.Fatal("Unexpected error attacking Alberta:", err)
+	}
+	if err := game.Occupy(0, 1); err != nil {
+		t.Fatal("Unexpected error occupying Alberta:", err)
+	}
+	if game.Phase == GameOverPhase {
+		t.Fatal("Expected the game to carry on while player 2 holds their capital")
+	}
+
+	if _, err := game.Attack(0, "Alaska", "Kamchatka", 1); err != nil {
+		t.Fatal("Unexpected error attacking Kamchatka:", err)
+	}
+	if err := game.Occupy(0, 1); err != nil {
+		t.Fatal("Unexpected error occupying Kamchatka:", err)
+	}
+	if game.Phase != GameOverPhase || len(game.Winners) != 1 || game.Winners[0] != 0 {
+		t.Errorf("Expected player 0 to win by holding every capital, got: phase %q, winners %v", game.Phase, game.Winners)
+	}
+}
@@ -0,0 +1,161 @@
+package game
+
+// WildTerritory is the territory printed on the wild cards
+const WildTerritory = "Myjäss"
+
+// goldenCavalryTrack lists the number of armies each successive trade of cards is worth
+// The Golden Cavalry marker starts on the first space and moves up one space after every trade
+var goldenCavalryTrack = []int{4, 6, 8, 10, 12, 15, 20, 25, 30, 35, 40, 45, 50, 55, 60}
+
+// maxHandSize is the number of cards a player may hold at the start of their turn before they are forced to trade
+const maxHandSize = 4
+
+// maxEliminationHandSize is the number of cards a player may hold after taking the cards of a player they eliminated
+// Holding any more forces them to trade immediately until they are back down to maxHandSize
+const maxEliminationHandSize = 5
+
+// territoryBonus is the number of extra armies placed on a territory the player owns when they trade in its card
+const territoryBonus = 2
+
+type Trade struct {
+	Cards  []Card `json:"cards"`
+	Armies int    `json:"armies"`
+	// BonusTerritory is the territory that received extra armies because the player owns a traded card's territory
+	BonusTerritory string `json:"bonusTerritory"`
+}
+
+// IsValidSet reports whether the three cards can be traded in together
+// A set is three cards of the same army type, or one of each army type, and a wild card completes any set
+func IsValidSet(cards []Card) bool {
+	if len(cards) != 3 {
+		return false
+	}
+
+	types := make(map[Army]int)
+	for _, card := range cards {
+		types[card.ArmyType]++
+	}
+	if types[Wild] > 0 {
+		return true
+	}
+	return len(types) == 1 || len(types) == 3
+}
+
+// TradeCards trades in a set of the player's cards for reinforcements during their reinforce phase,
+// or during their attack phase when eliminating another player forces them to trade
+// The set is worth the armies shown by the Golden Cavalry marker, which then advances
+func (g *Game) TradeCards(playerID int, cards []Card) (*Trade, error) {
+	if err := g.checkTurn(playerID, ReinforcePhase, AttackPhase); err != nil {
+		return nil, err
+	}
+	if g.Phase == AttackPhase && !g.ForcedTrade {
+		return nil, &WrongPhaseError{Phase: g.Phase, Allowed: []Phase{ReinforcePhase}}
+	}
+	if !IsValidSet(cards) {
+		return nil, &InvalidCardSetError{Cards: cards}
+	}
+
+	// Make sure the player holds every card before taking any of them
+	hand := append([]Card{}, g.Cards.OwnedBy[playerID]...)
+	for _, card := range cards {
+		i := indexOfCard(hand, card)
+		if i < 0 {
+			return nil, &CardNotOwnedError{Card: card, PlayerID: playerID}
+		}
+		hand = append(hand[:i], hand[i+1:]...)
+	}
+	g.record(&CardsTraded{PlayerID: playerID, Cards: append([]Card{}, cards...)})
+	g.Cards.OwnedBy[playerID] = hand
+	g.Cards.DiscardPile = append(g.Cards.DiscardPile, cards...)
+
+	trade := &Trade{
+		Cards:  cards,
+		Armies: g.GoldenCavalry,
+	}
+	g.advanceGoldenCavalry()
+
+	// The player receives the bonus for at most one card whose territory they own
+	for _, card := range cards {
+		if t, ok := g.Territories[card.Territory]; ok && t.isOwnedBy(playerID) {
+			t.AddArmies(territoryBonus)
+			trade.BonusTerritory = card.Territory
+			break
+		}
+	}
+
+	g.Reserves[playerID] += trade.Armies
+	if g.Reinforcement != nil {
+		g.Reinforcement.Cards += trade.Armies
+		g.Reinforcement.Total += trade.Armies
+	}
+
+	if len(hand) <= maxHandSize {
+		g.ForcedTrade = false
+	}
+
+	g.checkVictory()
+	return trade, nil
+}
+
+// advanceGoldenCavalry moves the Golden Cavalry marker up to the next space on the track
+// The marker stays put once it reaches the end of the track
+func (g *Game) advanceGoldenCavalry() {
+	for i, armies := range goldenCavalryTrack {
+		if armies > g.GoldenCavalry {
+			g.GoldenCavalry = goldenCavalryTrack[i]
+			return
+		}
+	}
+}
+
+// checkHandSize returns an error if the player holds too many cards and must trade some in
+func (g *Game) checkHandSize(playerID int) error {
+	if g.ForcedTrade || (g.Phase == ReinforcePhase && len(g.Cards.OwnedBy[playerID]) > maxHandSize) {
+		return &MustTradeCardsError{Cards: len(g.Cards.OwnedBy[playerID])}
+	}
+	return nil
+}
+
+// drawCard moves the top card of the draw pile into the player's hand
+// When the draw pile runs out the discard pile is shuffled to form a new draw pile
+func (g *Game) drawCard(playerID int) {
+	if len(g.Cards.DrawPile) == 0 {
+		g.Cards.DrawPile, g.Cards.DiscardPile = g.Cards.DiscardPile, []Card{}
+		g.shuffleCards()
+	}
+	if len(g.Cards.DrawPile) == 0 {
+		// Every card is in a player's hand
+		return
+	}
+
+	card := g.Cards.DrawPile[0]
+	g.Cards.DrawPile = g.Cards.DrawPile[1:]
+	g.Cards.OwnedBy[playerID] = append(g.Cards.OwnedBy[playerID], card)
+}
+
+// takeCards moves every card held by the eliminated player into the hand of the player who eliminated them
+// The victor must trade immediately if this leaves them with too many cards
+func (g *Game) takeCards(victorID, eliminatedID int) {
+	g.Cards.OwnedBy[victorID] = append(g.Cards.OwnedBy[victorID], g.Cards.OwnedBy[eliminatedID]...)
+	g.Cards.OwnedBy[eliminatedID] = []Card{}
+
+	if len(g.Cards.OwnedBy[victorID]) > maxEliminationHandSize {
+		g.ForcedTrade = true
+	}
+}
+
+func (g *Game) shuffleCards() {
+	pile := g.Cards.DrawPile
+	g.random().Shuffle(len(pile), func(i, j int) {
+		pile[i], pile[j] = pile[j], pile[i]
+	})
+}
+
+func indexOfCard(cards []Card, card Card) int {
+	for i, c := range cards {
+		if c == card {
+			return i
+		}
+	}
+	return -1
+}
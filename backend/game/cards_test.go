@@ -0,0 +1,248 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestIsValidSet(t *testing.T) {
+	wild := Card{Territory: WildTerritory, ArmyType: Wild}
+	alaska := Card{Territory: "Alaska", ArmyType: Infantry}
+	alberta := Card{Territory: "Alberta", ArmyType: Cavalry}
+	ontario := Card{Territory: "Ontario", ArmyType: Artillery}
+	peru := Card{Territory: "Peru", ArmyType: Cavalry}
+	congo := Card{Territory: "Congo", ArmyType: Infantry}
+	japan := Card{Territory: "Japan", ArmyType: Infantry}
+
+	testCases := []struct {
+		name  string
+		cards []Card
+		valid bool
+	}{
+		{name: "ThreeOfAKind", cards: []Card{alaska, congo, japan}, valid: true},
+		{name: "OneOfEach", cards: []Card{alaska, alberta, ontario}, valid: true},
+		{name: "WildPair", cards: []Card{alberta, peru, wild}, valid: true},
+		{name: "WildMixed", cards: []Card{alaska, ontario, wild}, valid: true},
+		{name: "TwoWilds", cards: []Card{alaska, wild, wild}, valid: true},
+		{name: "TwoOfAKind", cards: []Card{alaska, congo, peru}, valid: false},
+		{name: "TooFew", cards: []Card{alaska, congo}, valid: false},
+		{name: "TooMany", cards: []Card{alaska, congo, japan, wild}, valid: false},
+	}
+
+	for _, testCase := range testCases {
+		if IsValidSet(testCase.cards) != testCase.valid {
+			t.Errorf("%s: Expected IsValidSet to be %t", testCase.name, testCase.valid)
+		}
+	}
+}
+
+// newTradeGame returns a game in player 0's reinforce phase, with player 0 owning Alaska and holding the given cards
+func newTradeGame(t *testing.T, hand ...Card) *Game {
+	game := newTestGame(t, Options{})
+	giveTerritories(game, "Alaska")
+	game.Reserves = map[int]int{0: 0, 1: 0, 2: 0}
+	game.beginTurns()
+	game.Cards.OwnedBy[0] = hand
+	return game
+}
+
+func TestTradeCards(t *testing.T) {
+	wild := Card{Territory: WildTerritory, ArmyType: Wild}
+	alaska := Card{Territory: "Alaska", ArmyType: Infantry}
+	congo := Card{Territory: "Congo", ArmyType: Infantry}
+	japan := Card{Territory: "Japan", ArmyType: Infantry}
+	peru := Card{Territory: "Peru", ArmyType: Cavalry}
+
+	game := newTradeGame(t, alaska, congo, japan, peru)
+	reserves := game.Reserves[0]
+
+	var invalidSet *InvalidCardSetError
+	if _, err := game.TradeCards(0, []Card{alaska, congo, peru}); !errors.As(err, &invalidSet) {
+		t.Errorf("Expected an InvalidCardSetError trading two of a kind, got: %v", err)
+	}
+	var notOwned *CardNotOwnedError
+	if _, err := game.TradeCards(0, []Card{alaska, congo, wild}); !errors.As(err, &notOwned) {
+		t.Errorf("Expected a CardNotOwnedError trading a card the player doesn't hold, got: %v", err)
+	}
+	if len(game.Cards.OwnedBy[0]) != 4 {
+		t.Errorf("A failed trade should leave the player's hand alone, got: %v", game.Cards.OwnedBy[0])
+	}
+
+	trade, err := game.TradeCards(0, []Card{alaska, congo, japan})
+	if err != nil {
+		t.Fatal("Unexpected error trading cards:", err)
+	}
+	if trade.Armies != 4 {
+		t.Errorf("Expected the first trade to be worth 4 armies, got: %d", trade.Armies)
+	}
+	if game.GoldenCavalry != 6 {
+		t.Errorf("Expected the Golden Cavalry to advance to 6, got: %d", game.GoldenCavalry)
+	}
+	if game.Reserves[0] != reserves+4 || game.Reinforcement.Cards != 4 {
+		t.Errorf("Expected the trade to add 4 armies to the player's reinforcements, got: %d and %v", game.Reserves[0]-reserves, game.Reinforcement)
+	}
+	if trade.BonusTerritory != "Alaska" || game.Territories["Alaska"].Strength() != 3 {
+		t.Errorf("Expected 2 bonus armies on Alaska, got: %q with %d armies", trade.BonusTerritory, game.Territories["Alaska"].Strength())
+	}
+	if len(game.Cards.OwnedBy[0]) != 1 || game.Cards.OwnedBy[0][0] != peru {
+		t.Errorf("Expected the player to be left holding Peru, got: %v", game.Cards.OwnedBy[0])
+	}
+	if len(game.Cards.DiscardPile) != 3 {
+		t.Errorf("Expected the traded cards to be discarded, got: %v", game.Cards.DiscardPile)
+	}
+}
+
+func TestGoldenCavalry(t *testing.T) {
+	game := newTestGame(t, Options{})
+	want := []int{6, 8, 10, 12, 15, 20, 25, 30, 35, 40, 45, 50, 55, 60, 60}
+	for _, armies := range want {
+		game.advanceGoldenCavalry()
+		if game.GoldenCavalry != armies {
+			t.Errorf("Expected the Golden Cavalry to advance to %d, got: %d", armies, game.GoldenCavalry)
+		}
+	}
+}
+
+func TestForcedTrade(t *testing.T) {
+	hand := []Card{
+		Card{Territory: "Alaska", ArmyType: Infantry},
+		Card{Territory: "Congo", ArmyType: Infantry},
+		Card{Territory: "Japan", ArmyType: Infantry},
+		Card{Territory: "Peru", ArmyType: Cavalry},
+		Card{Territory: "Ontario", ArmyType: Artillery},
+	}
+	game := newTradeGame(t, hand...)
+
+	var mustTrade *MustTradeCardsError
+	if err := game.PlaceReinforcements(0, map[string]int{"Alaska": 1}); !errors.As(err, &mustTrade) {
+		t.Errorf("Expected a MustTradeCardsError placing armies while holding five cards, got: %v", err)
+	}
+	if err := game.EndTurn(0); !errors.As(err, &mustTrade) {
+		t.Errorf("Expected a MustTradeCardsError ending the turn while holding five cards, got: %v", err)
+	}
+
+	if _, err := game.TradeCards(0, hand[:3]); err != nil {
+		t.Fatal("Unexpected error trading cards:", err)
+	}
+	placeReinforcements(t, game)
+	if err := game.EndTurn(0); err != nil {
+		t.Error("Unexpected error ending the turn after trading:", err)
+	}
+}
+
+func TestDrawCard(t *testing.T) {
+	game := newTestGame(t, Options{})
+	if len(game.Cards.DrawPile) != 44 {
+		t.Fatalf("Expected a draw pile of 44 cards, got: %d", len(game.Cards.DrawPile))
+	}
+
+	top := game.Cards.DrawPile[0]
+	game.drawCard(1)
+	if len(game.Cards.OwnedBy[1]) != 1 || game.Cards.OwnedBy[1][0] != top {
+		t.Errorf("Expected player 1 to draw the top card, got: %v", game.Cards.OwnedBy[1])
+	}
+
+	// Once the draw pile runs out, the discard pile is reshuffled into a new draw pile
+	game.Cards.DiscardPile = game.Cards.DrawPile
+	game.Cards.DrawPile = []Card{}
+	game.drawCard(1)
+	if len(game.Cards.OwnedBy[1]) != 2 || len(game.Cards.DrawPile) != 42 || len(game.Cards.DiscardPile) != 0 {
+		t.Errorf("Expected the discard pile to be reshuffled, got %d cards in the draw pile and %d in the discard pile", len(game.Cards.DrawPile), len(game.Cards.DiscardPile))
+	}
+}
+
+func TestConquestCard(t *testing.T) {
+	game := newAttackGame(t, 6, 1)
+	game.Territories["Alaska"].Armies[Infantry] = 5
+
+	// A turn without a conquest doesn't earn a card
+	if err := game.EndTurn(0); err != nil {
+		t.Fatal("Unexpected error ending the turn:", err)
+	}
+	if len(game.Cards.OwnedBy[0]) != 0 {
+		t.Errorf("Expected no card for a turn without a conquest, got: %v", game.Cards.OwnedBy[0])
+	}
+
+	game = newAttackGame(t, 6, 1)
+	game.Territories["Alaska"].Armies[Infantry] = 5
+	for _, target := range []string{"Alberta", "Kamchatka"} {
+		if _, err := game.Attack(0, "Alaska", target, 1); err != nil {
+			t.Fatalf("Unexpected error attacking %q: %s", target, err)
+		}
+		if err := game.Occupy(0, 1); err != nil {
+			t.Fatalf("Unexpected error occupying %q: %s", target, err)
+		}
+	}
+	if !game.ConqueredThisTurn {
+		t.Error("Expected the game to record that player 0 conquered a territory this turn")
+	}
+	top := game.Cards.DrawPile[0]
+	if err := game.EndTurn(0); err != nil {
+		t.Fatal("Unexpected error ending the turn:", err)
+	}
+	if len(game.Cards.OwnedBy[0]) != 1 || game.Cards.OwnedBy[0][0] != top {
+		t.Errorf("Expected player 0 to draw exactly one card after conquering two territories, got: %v", game.Cards.OwnedBy[0])
+	}
+	if game.ConqueredThisTurn {
+		t.Error("Expected the next turn to start without any conquests")
+	}
+}
+
+func TestEliminationCards(t *testing.T) {
+	game := newAttackGame(t, 6, 1)
+	game.Territories["Alaska"].Armies[Infantry] = 5
+	game.Territories["Alberta"].OwnedBy = &game.Players[2]
+	game.Cards.OwnedBy[0] = []Card{
+		Card{Territory: "Alaska", ArmyType: Infantry},
+		Card{Territory: "Congo", ArmyType: Infantry},
+	}
+	game.Cards.OwnedBy[2] = []Card{
+		Card{Territory: "Japan", ArmyType: Infantry},
+		Card{Territory: "Peru", ArmyType: Cavalry},
+		Card{Territory: "Ontario", ArmyType: Artillery},
+		Card{Territory: WildTerritory, ArmyType: Wild},
+	}
+
+	if _, err := game.Attack(0, "Alaska", "Alberta", 1); err != nil {
+		t.Fatal("Unexpected error attacking:", err)
+	}
+	if len(game.Cards.OwnedBy[0]) != 6 || len(game.Cards.OwnedBy[2]) != 0 {
+		t.Fatalf("Expected the eliminated player's cards to pass to the victor, got: %v and %v", game.Cards.OwnedBy[0], game.Cards.OwnedBy[2])
+	}
+	if !game.ForcedTrade {
+		t.Error("Expected holding six cards to force a trade")
+	}
+	if err := game.Occupy(0, 1); err != nil {
+		t.Fatal("Unexpected error occupying Alberta:", err)
+	}
+
+	var mustTrade *MustTradeCardsError
+	if _, err := game.Attack(0, "Alberta", "Ontario", 1); !errors.As(err, &mustTrade) {
+		t.Errorf("Expected a MustTradeCardsError attacking before the forced trade, got: %v", err)
+	}
+	if err := game.EndTurn(0); !errors.As(err, &mustTrade) {
+		t.Errorf("Expected a MustTradeCardsError ending the turn before the forced trade, got: %v", err)
+	}
+
+	if _, err := game.TradeCards(0, game.Cards.OwnedBy[0][2:5]); err != nil {
+		t.Fatal("Unexpected error making the forced trade:", err)
+	}
+	if game.ForcedTrade {
+		t.Error("Expected trading down to three cards to end the forced trade")
+	}
+
+	var unplaced *UnplacedArmiesError
+	if _, err := game.Attack(0, "Alberta", "Ontario", 1); !errors.As(err, &unplaced) {
+		t.Errorf("Expected an UnplacedArmiesError attacking before placing the traded armies, got: %v", err)
+	}
+	placeReinforcements(t, game)
+
+	// Without a forced trade, cards can't be traded during the attack phase
+	var wrongPhase *WrongPhaseError
+	if _, err := game.TradeCards(0, game.Cards.OwnedBy[0]); !errors.As(err, &wrongPhase) {
+		t.Errorf("Expected a WrongPhaseError trading cards during the attack phase, got: %v", err)
+	}
+	if err := game.EndTurn(0); err != nil {
+		t.Error("Unexpected error ending the turn:", err)
+	}
+}
@@ -0,0 +1,202 @@
+package game
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// Type Dice is a source of dice rolls
+// Roll must return a number between 1 and 6
+type Dice interface {
+	Roll() int
+}
+
+type randomDice struct {
+	rng *rand.Rand
+}
+
+func (d *randomDice) Roll() int {
+	return d.rng.Intn(6) + 1
+}
+
+// SetDice replaces the dice the game rolls during combat
+// This allows tests and replays to use a deterministic sequence of rolls
+func (g *Game) SetDice(dice Dice) {
+	g.dice = dice
+}
+
+// roll rolls the given number of dice and returns them sorted from highest to lowest
+func (g *Game) roll(n int) []int {
+	// The dice don't share the game's source of randomness, since replays roll the recorded dice instead
+	if g.dice == nil {
+		g.dice = &randomDice{rng: rand.New(rand.NewSource(newSeed()))}
+	}
+	rolls := make([]int, n)
+	for i := range rolls {
+		rolls[i] = g.dice.Roll()
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(rolls)))
+	return rolls
+}
+
+// Type Conquest is a territory that has just been conquered, which the attacker must move armies into
+type Conquest struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	// The attacker must move at least as many armies as they rolled dice in the conquering attack,
+	// and must leave at least one army behind
+	MinArmies int `json:"minArmies"`
+	MaxArmies int `json:"maxArmies"`
+}
+
+type AttackResult struct {
+	From           string `json:"from"`
+	To             string `json:"to"`
+	AttackerRolls  []int  `json:"attackerRolls"`
+	DefenderRolls  []int  `json:"defenderRolls"`
+	AttackerLosses int    `json:"attackerLosses"`
+	DefenderLosses int    `json:"defenderLosses"`
+	Conquered      bool   `json:"conquered"`
+	// Eliminated is set when the conquest knocked the defender out of the game
+	Eliminated bool `json:"eliminated"`
+}
+
+// Attack has the player attack the territory to from the adjacent territory from, rolling the given number of dice
+// The defender rolls two dice if they have at least two armies, otherwise one,
+// unless the defender is a neutral player whose dice are chosen by the attacker's opponent
+// If the attack conquers the territory, the attacker must then Occupy it before doing anything else
+func (g *Game) Attack(playerID int, from, to string, attackerDice int) (*AttackResult, error) {
+	if err := g.checkTurn(playerID, AttackPhase); err != nil {
+		return nil, err
+	}
+	if err := g.checkNoPendingConquest(); err != nil {
+		return nil, err
+	}
+	if err := g.checkHandSize(playerID); err != nil {
+		return nil, err
+	}
+	if err := g.checkReinforcementsPlaced(playerID); err != nil {
+		return nil, err
+	}
+
+	attacker, ok := g.Territories[from]
+	if !ok {
+		return nil, &UnknownTerritoryError{Name: from}
+	}
+	defender, ok := g.Territories[to]
+	if !ok {
+		return nil, &UnknownTerritoryError{Name: to}
+	}
+	if !attacker.isOwnedBy(playerID) {
+		return nil, &TerritoryNotOwnedError{Territory: from, PlayerID: playerID}
+	}
+	if defender.OwnedBy == nil {
+		return nil, &UnownedTerritoryError{Territory: to}
+	}
+	if defender.isOwnedBy(playerID) {
+		return nil, &AttackOwnTerritoryError{Territory: to}
+	}
+	if g.isAllied(defender, playerID) {
+		return nil, &AttackTeammateError{Territory: to, TeammateID: defender.OwnedBy.ID}
+	}
+	if !attacker.linksTo(to) {
+		return nil, &TerritoriesNotAdjacentError{From: from, To: to}
+	}
+	if attackerDice < 1 || attackerDice > 3 {
+		return nil, &InvalidDiceError{Dice: attackerDice, Max: 3}
+	}
+	// The attacker must leave at least one army behind in the territory they attack from
+	if attacker.Strength() <= attackerDice {
+		return nil, &InsufficientArmiesError{Territory: from, Armies: attacker.Strength(), Needed: attackerDice + 1}
+	}
+
+	// The opponent chooses how many dice the neutral player defends with, ahead of time with SetNeutralDice
+	defenderDice := 2
+	if defender.OwnedBy.Neutral && g.NeutralDice[g.opponent(playerID)] > 0 {
+		defenderDice = g.NeutralDice[g.opponent(playerID)]
+	}
+	if defender.Strength() < defenderDice {
+		defenderDice = defender.Strength()
+	}
+
+	result := &AttackResult{
+		From:          from,
+		To:            to,
+		AttackerRolls: g.roll(attackerDice),
+		DefenderRolls: g.roll(defenderDice),
+	}
+	g.record(&Attacked{
+		PlayerID:      playerID,
+		From:          from,
+		To:            to,
+		Dice:          attackerDice,
+		AttackerRolls: result.AttackerRolls,
+		DefenderRolls: result.DefenderRolls,
+	})
+
+	// Compare the highest dice of each side, then the second highest
+	// The defender wins ties
+	for i := 0; i < len(result.AttackerRolls) && i < len(result.DefenderRolls); i++ {
+		if result.AttackerRolls[i] > result.DefenderRolls[i] {
+			result.DefenderLosses++
+		} else {
+			result.AttackerLosses++
+		}
+	}
+
+	if err := attacker.RemoveArmies(result.AttackerLosses); err != nil {
+		return nil, err
+	}
+	if err := defender.RemoveArmies(result.DefenderLosses); err != nil {
+		return nil, err
+	}
+
+	if defender.Strength() == 0 {
+		result.Conquered = true
+		defenderID := defender.OwnedBy.ID
+		defender.OwnedBy = attacker.OwnedBy
+		g.ConqueredThisTurn = true
+		g.PendingConquest = &Conquest{
+			From:      from,
+			To:        to,
+			MinArmies: attackerDice,
+			MaxArmies: attacker.Strength() - 1,
+		}
+
+		if g.territoriesOwned(defenderID) == 0 {
+			result.Eliminated = true
+			g.eliminate(defenderID, playerID)
+		}
+	}
+
+	return result, nil
+}
+
+// Occupy moves the given number of armies into the territory the player has just conquered
+func (g *Game) Occupy(playerID int, armies int) error {
+	if err := g.checkTurn(playerID, AttackPhase); err != nil {
+		return err
+	}
+	conquest := g.PendingConquest
+	if conquest == nil {
+		return &NoPendingConquestError{}
+	}
+	if armies < conquest.MinArmies || armies > conquest.MaxArmies {
+		return &InvalidArmiesError{Armies: armies, Min: conquest.MinArmies, Max: conquest.MaxArmies}
+	}
+	g.record(&Occupied{PlayerID: playerID, Armies: armies})
+
+	if err := g.Territories[conquest.From].moveArmies(g.Territories[conquest.To], armies); err != nil {
+		return err
+	}
+	g.PendingConquest = nil
+	g.checkVictory()
+	return nil
+}
+
+func (g *Game) checkNoPendingConquest() error {
+	if g.PendingConquest != nil {
+		return &PendingConquestError{Territory: g.PendingConquest.To}
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package game
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// Type fixedDice rolls a fixed sequence of dice, starting over once it runs out
+type fixedDice struct {
+	rolls []int
+	next  int
+}
+
+func (d *fixedDice) Roll() int {
+	roll := d.rolls[d.next%len(d.rolls)]
+	d.next++
+	return roll
+}
+
+// newAttackGame returns a game in player 0's attack phase, with player 0 owning Alaska and
+// player 1 owning every other territory
+func newAttackGame(t *testing.T, rolls ...int) *Game {
+	game := newTestGame(t, Options{})
+	for name, territory := range game.Territories {
+		territory.OwnedBy = &game.Players[1]
+		territory.Armies = map[Army]int{Infantry: 1, Cavalry: 0, Artillery: 0}
+		if name == "Alaska" {
+			territory.OwnedBy = &game.Players[0]
+		}
+	}
+	game.beginTurns()
+	game.Reserves = map[int]int{0: 0, 1: 0, 2: 0}
+	game.Phase = AttackPhase
+	game.SetDice(&fixedDice{rolls: rolls})
+	return game
+}
+
+func TestAttackValidation(t *testing.T) {
+	game := newAttackGame(t, 6)
+	game.Territories["Alaska"].Armies[Infantry] = 3
+
+	var notOwned *TerritoryNotOwnedError
+	if _, err := game.Attack(0, "Alberta", "Ontario", 1); !errors.As(err, &notOwned) {
+		t.Errorf("Expected a TerritoryNotOwnedError when attacking from another player's territory, got: %v", err)
+	}
+	var notAdjacent *TerritoriesNotAdjacentError
+	if _, err := game.Attack(0, "Alaska", "Ontario", 1); !errors.As(err, &notAdjacent) {
+		t.Errorf("Expected a TerritoriesNotAdjacentError when attacking Ontario from Alaska, got: %v", err)
+	}
+	var invalidDice *InvalidDiceError
+	if _, err := game.Attack(0, "Alaska", "Alberta", 4); !errors.As(err, &invalidDice) {
+		t.Errorf("Expected an InvalidDiceError when attacking with four dice, got: %v", err)
+	}
+	var insufficient *InsufficientArmiesError
+	if _, err := game.Attack(0, "Alaska", "Alberta", 3); !errors.As(err, &insufficient) {
+		t.Errorf("Expected an InsufficientArmiesError when attacking with three dice from three armies, got: %v", err)
+	}
+	var notYourTurn *NotYourTurnError
+	if _, err := game.Attack(1, "Alberta", "Alaska", 1); !errors.As(err, &notYourTurn) {
+		t.Errorf("Expected a NotYourTurnError when player 1 attacks during player 0's turn, got: %v", err)
+	}
+
+	game.Territories["Alberta"].OwnedBy = &game.Players[0]
+	var ownTerritory *AttackOwnTerritoryError
+	if _, err := game.Attack(0, "Alaska", "Alberta", 1); !errors.As(err, &ownTerritory) {
+		t.Errorf("Expected an AttackOwnTerritoryError when attacking your own territory, got: %v", err)
+	}
+
+	game.Territories["Kamchatka"].OwnedBy = nil
+	var unowned *UnownedTerritoryError
+	if _, err := game.Attack(0, "Alaska", "Kamchatka", 1); !errors.As(err, &unowned) {
+		t.Errorf("Expected an UnownedTerritoryError when attacking a territory nobody owns, got: %v", err)
+	}
+}
+
+func TestAttackDice(t *testing.T) {
+	testCases := []struct {
+		name           string
+		defenders      int
+		rolls          []int
+		attackerDice   int
+		attackerRolls  []int
+		defenderRolls  []int
+		attackerLosses int
+		defenderLosses int
+	}{
+		{
+			name:           "AttackerWinsBoth",
+			defenders:      5,
+			rolls:          []int{3, 6, 5, 4, 2},
+			attackerDice:   3,
+			attackerRolls:  []int{6, 5, 3},
+			defenderRolls:  []int{4, 2},
+			attackerLosses: 0,
+			defenderLosses: 2,
+		},
+		{
+			name:           "DefenderWinsTies",
+			defenders:      5,
+			rolls:          []int{4, 4, 1, 4, 4},
+			attackerDice:   3,
+			attackerRolls:  []int{4, 4, 1},
+			defenderRolls:  []int{4, 4},
+			attackerLosses: 2,
+			defenderLosses: 0,
+		},
+		{
+			name:           "SplitLosses",
+			defenders:      5,
+			rolls:          []int{6, 2, 5, 3},
+			attackerDice:   2,
+			attackerRolls:  []int{6, 2},
+			defenderRolls:  []int{5, 3},
+			attackerLosses: 1,
+			defenderLosses: 1,
+		},
+		{
+			name:           "SingleDefender",
+			defenders:      1,
+			rolls:          []int{2, 3, 1, 3},
+			attackerDice:   3,
+			attackerRolls:  []int{3, 2, 1},
+			defenderRolls:  []int{3},
+			attackerLosses: 1,
+			defenderLosses: 0,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			game := newAttackGame(t, testCase.rolls...)
+			game.Territories["Alaska"].Armies[Infantry] = 10
+			game.Territories["Alberta"].Armies[Infantry] = testCase.defenders
+
+			result, err := game.Attack(0, "Alaska", "Alberta", testCase.attackerDice)
+			if err != nil {
+				t.Fatal("Unexpected error attacking:", err)
+			}
+			if !reflect.DeepEqual(result.AttackerRolls, testCase.attackerRolls) {
+				t.Errorf("Expected attacker rolls %v, got: %v", testCase.attackerRolls, result.AttackerRolls)
+			}
+			if !reflect.DeepEqual(result.DefenderRolls, testCase.defenderRolls) {
+				t.Errorf("Expected defender rolls %v, got: %v", testCase.defenderRolls, result.DefenderRolls)
+			}
+			if result.AttackerLosses != testCase.attackerLosses || result.DefenderLosses != testCase.defenderLosses {
+				t.Errorf("Expected losses %d/%d, got: %d/%d", testCase.attackerLosses, testCase.defenderLosses, result.AttackerLosses, result.DefenderLosses)
+			}
+			if game.Territories["Alaska"].Strength() != 10-testCase.attackerLosses {
+				t.Errorf("Expected Alaska to have %d armies, got: %d", 10-testCase.attackerLosses, game.Territories["Alaska"].Strength())
+			}
+			if game.Territories["Alberta"].Strength() != testCase.defenders-testCase.defenderLosses {
+				t.Errorf("Expected Alberta to have %d armies, got: %d", testCase.defenders-testCase.defenderLosses, game.Territories["Alberta"].Strength())
+			}
+		})
+	}
+}
+
+func TestConquest(t *testing.T) {
+	game := newAttackGame(t, 6, 6, 1)
+	game.Territories["Alaska"].Armies[Infantry] = 5
+
+	result, err := game.Attack(0, "Alaska", "Alberta", 2)
+	if err != nil {
+		t.Fatal("Unexpected error attacking:", err)
+	}
+	if !result.Conquered {
+		t.Fatal("Expected Alberta to be conquered")
+	}
+	if !game.Territories["Alberta"].isOwnedBy(0) {
+		t.Error("Expected Alberta to be owned by player 0 after being conquered")
+	}
+
+	// Nothing else can happen until the attacker has moved into Alberta
+	var pending *PendingConquestError
+	if _, err := game.Attack(0, "Alaska", "Kamchatka", 1); !errors.As(err, &pending) {
+		t.Errorf("Expected a PendingConquestError when attacking before occupying, got: %v", err)
+	}
+	if err := game.EndTurn(0); !errors.As(err, &pending) {
+		t.Errorf("Expected a PendingConquestError when ending the turn before occupying, got: %v", err)
+	}
+
+	var invalidArmies *InvalidArmiesError
+	if err := game.Occupy(0, 1); !errors.As(err, &invalidArmies) {
+		t.Errorf("Expected an InvalidArmiesError when moving in fewer armies than dice rolled, got: %v", err)
+	}
+	if err := game.Occupy(0, 5); !errors.As(err, &invalidArmies) {
+		t.Errorf("Expected an InvalidArmiesError when leaving no armies behind, got: %v", err)
+	}
+
+	if err := game.Occupy(0, 3); err != nil {
+		t.Fatal("Unexpected error occupying Alberta:", err)
+	}
+	if game.Territories["Alaska"].Strength() != 2 || game.Territories["Alberta"].Strength() != 3 {
+		t.Errorf("Expected Alaska and Alberta to have 2 and 3 armies, got: %d and %d", game.Territories["Alaska"].Strength(), game.Territories["Alberta"].Strength())
+	}
+
+	var noConquest *NoPendingConquestError
+	if err := game.Occupy(0, 1); !errors.As(err, &noConquest) {
+		t.Errorf("Expected a NoPendingConquestError when occupying twice, got: %v", err)
+	}
+}
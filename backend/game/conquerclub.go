@@ -0,0 +1,138 @@
+package game
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+type conquerClubMap struct {
+	Continents     []conquerClubContinent `xml:"continent"`
+	Territories    []conquerClubTerritory `xml:"territory"`
+	Objectives     []xmlElement           `xml:"objective"`
+	Positions      []xmlElement           `xml:"positions"`
+	Reinforcements []xmlElement           `xml:"reinforcements"`
+}
+
+type conquerClubContinent struct {
+	Name        string       `xml:"name"`
+	Bonus       int          `xml:"bonus"`
+	Territories []string     `xml:"components>territory"`
+	Continents  []string     `xml:"components>continent"`
+	Required    []xmlElement `xml:"required"`
+	Overrides   []xmlElement `xml:"overrides"`
+}
+
+type conquerClubTerritory struct {
+	Name         string       `xml:"name"`
+	Borders      []string     `xml:"borders>border"`
+	Bombardments []string     `xml:"bombardments>bombardment"`
+	Neutral      []xmlElement `xml:"neutral"`
+	Bonus        []xmlElement `xml:"bonus"`
+	X            float64      `xml:"coordinates>smallx"`
+	Y            float64      `xml:"coordinates>smally"`
+}
+
+// xmlElement matches any element whose contents the importer doesn't need
+type xmlElement struct {
+	Inner string `xml:",innerxml"`
+}
+
+// ImportConquerClubMap converts a Conquer Club XML map definition into a map with the given name
+// Conquer Club maps don't define cards, so the deck is dealt out evenly between the army types
+func ImportConquerClubMap(r io.Reader, name string) (*Import, error) {
+	var cc conquerClubMap
+	if err := xml.NewDecoder(r).Decode(&cc); err != nil {
+		return nil, err
+	}
+
+	imp := &Import{
+		Map:         &Map{Name: name},
+		Unsupported: []string{},
+	}
+	unsupported := func(format string, a ...interface{}) {
+		imp.Unsupported = append(imp.Unsupported, fmt.Sprintf(format, a...))
+	}
+
+	if len(cc.Objectives) > 0 {
+		unsupported("Objectives are not supported")
+	}
+	if len(cc.Positions) > 0 {
+		unsupported("Starting positions are not supported")
+	}
+	if len(cc.Reinforcements) > 0 {
+		unsupported("Custom reinforcement rules are not supported")
+	}
+
+	// Each territory belongs to the first continent listing it
+	continentOf := make(map[string]string)
+	for _, c := range cc.Continents {
+		if len(c.Continents) > 0 {
+			unsupported("Continent %q is made up of other continents and was left out", c.Name)
+			continue
+		}
+		if c.Bonus < 0 {
+			unsupported("Continent %q has a negative bonus and was left out", c.Name)
+			continue
+		}
+		if len(c.Required) > 0 || len(c.Overrides) > 0 {
+			unsupported("Continent %q has required territories or overrides, which are ignored", c.Name)
+		}
+		imp.Map.Continents = append(imp.Map.Continents, Continent{Name: c.Name, Bonus: c.Bonus})
+		for _, t := range c.Territories {
+			if other, ok := continentOf[t]; ok {
+				unsupported("Territory %q is in continents %q and %q, and was kept in %q", t, other, c.Name, other)
+				continue
+			}
+			continentOf[t] = c.Name
+		}
+	}
+
+	borders := make(map[string]map[string]bool)
+	for _, t := range cc.Territories {
+		borders[t.Name] = make(map[string]bool)
+		for _, border := range t.Borders {
+			borders[t.Name][border] = true
+		}
+	}
+
+	for _, t := range cc.Territories {
+		if _, ok := continentOf[t.Name]; !ok {
+			unsupported("Territory %q is in no continent", t.Name)
+		}
+		if len(t.Bombardments) > 0 {
+			unsupported("Territory %q can bombard other territories, which is ignored", t.Name)
+		}
+		if len(t.Neutral) > 0 {
+			unsupported("Territory %q starts neutral, which is ignored", t.Name)
+		}
+		if len(t.Bonus) > 0 {
+			unsupported("Territory %q has its own bonus, which is ignored", t.Name)
+		}
+
+		// The game only supports borders that can be crossed both ways, so one-way borders are left out
+		links := []string{}
+		for _, border := range t.Borders {
+			if borders[border] == nil {
+				unsupported("Border from %q to unknown territory %q", t.Name, border)
+				continue
+			}
+			if !borders[border][t.Name] {
+				unsupported("One-way border from %q to %q", t.Name, border)
+				continue
+			}
+			links = append(links, border)
+		}
+
+		imp.Map.Territories = append(imp.Map.Territories, MapTerritory{
+			Name:      t.Name,
+			Continent: continentOf[t.Name],
+			Links:     links,
+			X:         t.X,
+			Y:         t.Y,
+		})
+	}
+
+	imp.Map.Cards = deck(imp.Map.Territories)
+	return imp, nil
+}
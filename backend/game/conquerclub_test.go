@@ -0,0 +1,97 @@
+package game
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testConquerClubMap = `<?xml version="1.0" encoding="UTF-8"?>
+<map>
+	<continent>
+		<name>North Land</name>
+		<bonus>2</bonus>
+		<components>
+			<territory>Alpha</territory>
+			<territory>Bravo</territory>
+		</components>
+	</continent>
+	<continent>
+		<name>South Land</name>
+		<bonus>1</bonus>
+		<components>
+			<territory>Charlie</territory>
+			<territory>Delta</territory>
+			<territory>Bravo</territory>
+		</components>
+	</continent>
+	<continent>
+		<name>Everywhere</name>
+		<bonus>5</bonus>
+		<components>
+			<continent>North Land</continent>
+			<continent>South Land</continent>
+		</components>
+	</continent>
+	<territory>
+		<name>Alpha</name>
+		<borders><border>Bravo</border><border>Charlie</border></borders>
+		<coordinates><smallx>10</smallx><smally>10</smally></coordinates>
+	</territory>
+	<territory>
+		<name>Bravo</name>
+		<borders><border>Alpha</border><border>Delta</border></borders>
+		<bombardments><bombardment>Charlie</bombardment></bombardments>
+	</territory>
+	<territory>
+		<name>Charlie</name>
+		<borders><border>Alpha</border><border>Delta</border></borders>
+		<neutral killer="yes">3</neutral>
+	</territory>
+	<territory>
+		<name>Delta</name>
+		<borders><border>Bravo</border><border>Charlie</border><border>Alpha</border><border>Echo</border></borders>
+	</territory>
+</map>`
+
+func TestImportConquerClubMap(t *testing.T) {
+	imp, err := ImportConquerClubMap(strings.NewReader(testConquerClubMap), "tiny")
+	if err != nil {
+		t.Fatal("Unexpected error importing the map:", err)
+	}
+
+	m := imp.Map
+	if m.Name != "tiny" || len(m.Continents) != 2 || len(m.Territories) != 4 || len(m.Cards) != 6 {
+		t.Fatalf("Expected 2 continents, 4 territories and 6 cards, got: %v", m)
+	}
+	if m.Territories[0].X != 10 || m.Territories[0].Y != 10 {
+		t.Errorf("Expected Alpha to be positioned at (10, 10), got: (%v, %v)", m.Territories[0].X, m.Territories[0].Y)
+	}
+	if m.Territories[1].Continent != "North Land" {
+		t.Errorf("Expected Bravo to be kept in North Land, got: %q", m.Territories[1].Continent)
+	}
+	if links := m.Territories[3].Links; !reflect.DeepEqual(links, []string{"Bravo", "Charlie"}) {
+		t.Errorf("Expected the one-way border from Delta to Alpha and the border to Echo to be left out, got: %v", links)
+	}
+
+	want := []string{
+		`Territory "Bravo" is in continents "North Land" and "South Land", and was kept in "North Land"`,
+		`Continent "Everywhere" is made up of other continents and was left out`,
+		`Territory "Bravo" can bombard other territories, which is ignored`,
+		`Territory "Charlie" starts neutral, which is ignored`,
+		`One-way border from "Delta" to "Alpha"`,
+		`Border from "Delta" to unknown territory "Echo"`,
+	}
+	if !reflect.DeepEqual(imp.Unsupported, want) {
+		t.Errorf("Expected the unsupported features %v, got: %v", want, imp.Unsupported)
+	}
+	if err := m.Validate(); err != nil {
+		t.Errorf("Expected the imported map to be valid, got: %s", err)
+	}
+}
+
+func TestImportConquerClubMapError(t *testing.T) {
+	if _, err := ImportConquerClubMap(strings.NewReader("<map><territory>"), "broken"); err == nil {
+		t.Error("Expected an error importing truncated XML")
+	}
+}
@@ -0,0 +1,131 @@
+package game
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ImportDominationMap converts a Domination .map file into a map with the given name
+// The territories are listed in the [countries] section by index, name, continent index and position,
+// and their neighbours in the [borders] section by index
+// Domination keeps its cards in a separate file, so the deck is dealt out evenly between the army types
+func ImportDominationMap(r io.Reader, name string) (*Import, error) {
+	imp := &Import{
+		Map:         &Map{Name: name},
+		Unsupported: []string{},
+	}
+	unsupported := func(format string, a ...interface{}) {
+		imp.Unsupported = append(imp.Unsupported, fmt.Sprintf(format, a...))
+	}
+
+	continents := []string{}
+	territories := make(map[int]int)
+	links := make(map[string]map[string]bool)
+	section := ""
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, ";") {
+			continue
+		}
+		if strings.HasPrefix(text, "[") && strings.HasSuffix(text, "]") {
+			section = strings.Trim(text, "[]")
+			switch section {
+			case "files", "continents", "countries", "borders":
+			default:
+				unsupported("Section [%s] is not supported", section)
+			}
+			continue
+		}
+
+		fields := strings.Fields(text)
+		switch section {
+		case "continents":
+			if len(fields) < 2 {
+				return nil, &MapImportError{Line: line, Text: text}
+			}
+			bonus, err := strconv.Atoi(fields[1])
+			if err != nil {
+				return nil, &MapImportError{Line: line, Text: text}
+			}
+			continents = append(continents, dominationName(fields[0]))
+			imp.Map.Continents = append(imp.Map.Continents, Continent{Name: dominationName(fields[0]), Bonus: bonus})
+		case "countries":
+			if len(fields) < 3 {
+				return nil, &MapImportError{Line: line, Text: text}
+			}
+			index, err := strconv.Atoi(fields[0])
+			if err != nil {
+				return nil, &MapImportError{Line: line, Text: text}
+			}
+			continent, err := strconv.Atoi(fields[2])
+			if err != nil {
+				return nil, &MapImportError{Line: line, Text: text}
+			}
+			t := MapTerritory{Name: dominationName(fields[1]), Links: []string{}}
+			if len(fields) >= 5 {
+				t.X, _ = strconv.ParseFloat(fields[3], 64)
+				t.Y, _ = strconv.ParseFloat(fields[4], 64)
+			}
+			if continent < 1 || continent > len(continents) {
+				unsupported("Territory %q is in no continent", t.Name)
+			} else {
+				t.Continent = continents[continent-1]
+			}
+			territories[index] = len(imp.Map.Territories)
+			imp.Map.Territories = append(imp.Map.Territories, t)
+		case "borders":
+			indices := []int{}
+			for _, field := range fields {
+				index, err := strconv.Atoi(field)
+				if err != nil {
+					return nil, &MapImportError{Line: line, Text: text}
+				}
+				if _, ok := territories[index]; !ok {
+					return nil, &MapImportError{Line: line, Text: text}
+				}
+				indices = append(indices, index)
+			}
+			if len(indices) == 0 {
+				continue
+			}
+			t := &imp.Map.Territories[territories[indices[0]]]
+			for _, index := range indices[1:] {
+				link := imp.Map.Territories[territories[index]].Name
+				t.Links = append(t.Links, link)
+				if links[t.Name] == nil {
+					links[t.Name] = make(map[string]bool)
+				}
+				links[t.Name][link] = true
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// The game only supports borders that can be crossed both ways, so one-way borders are left out
+	for i, t := range imp.Map.Territories {
+		both := []string{}
+		for _, link := range t.Links {
+			if !links[link][t.Name] {
+				unsupported("One-way border from %q to %q", t.Name, link)
+				continue
+			}
+			both = append(both, link)
+		}
+		imp.Map.Territories[i].Links = both
+	}
+
+	imp.Map.Cards = deck(imp.Map.Territories)
+	return imp, nil
+}
+
+// dominationName turns the underscores Domination uses in place of spaces back into spaces
+func dominationName(name string) string {
+	return strings.ReplaceAll(name, "_", " ")
+}
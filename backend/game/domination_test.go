@@ -0,0 +1,75 @@
+package game
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testDominationMap = `; A small Domination map
+[files]
+pic tiny_pic.png
+
+[continents]
+North_Land 2 red
+South_Land 1 blue
+
+[countries]
+1 Alpha 1 10 10
+2 Bravo 1 20 10
+3 Charlie 2 10 20
+4 Delta 2 20 20
+
+[borders]
+1 2 3
+2 1 4
+3 1 4
+4 2 3 1
+
+[missions]
+1 0 2 1 0 0 0 0 Conquer North Land
+`
+
+func TestImportDominationMap(t *testing.T) {
+	imp, err := ImportDominationMap(strings.NewReader(testDominationMap), "tiny")
+	if err != nil {
+		t.Fatal("Unexpected error importing the map:", err)
+	}
+
+	m := imp.Map
+	if m.Name != "tiny" || len(m.Continents) != 2 || len(m.Territories) != 4 || len(m.Cards) != 6 {
+		t.Fatalf("Expected 2 continents, 4 territories and 6 cards, got: %v", m)
+	}
+	if m.Continents[0] != (Continent{Name: "North Land", Bonus: 2}) {
+		t.Errorf("Expected North Land to be worth 2 armies, got: %v", m.Continents[0])
+	}
+	if m.Territories[1].X != 20 || m.Territories[1].Y != 10 {
+		t.Errorf("Expected Bravo to be positioned at (20, 10), got: (%v, %v)", m.Territories[1].X, m.Territories[1].Y)
+	}
+	if m.Territories[2].Continent != "South Land" {
+		t.Errorf("Expected Charlie to be in South Land, got: %q", m.Territories[2].Continent)
+	}
+	if links := m.Territories[3].Links; !reflect.DeepEqual(links, []string{"Bravo", "Charlie"}) {
+		t.Errorf("Expected the one-way border from Delta to Alpha to be left out, got: %v", links)
+	}
+
+	want := []string{
+		"Section [missions] is not supported",
+		`One-way border from "Delta" to "Alpha"`,
+	}
+	if !reflect.DeepEqual(imp.Unsupported, want) {
+		t.Errorf("Expected the unsupported features %v, got: %v", want, imp.Unsupported)
+	}
+	if err := m.Validate(); err != nil {
+		t.Errorf("Expected the imported map to be valid, got: %s", err)
+	}
+}
+
+func TestImportDominationMapError(t *testing.T) {
+	var importError *MapImportError
+	_, err := ImportDominationMap(strings.NewReader("[countries]\n1 Alpha one 10 10\n"), "broken")
+	if !errors.As(err, &importError) || importError.Line != 2 {
+		t.Errorf("Expected a MapImportError on line 2, got: %v", err)
+	}
+}
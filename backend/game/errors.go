@@ -2,14 +2,17 @@ package game
 
 import (
 	"fmt"
+	"strings"
 )
 
 type IncorrectNumberOfPlayersError struct {
 	NumPlayers int
+	Min        int
+	Max        int
 }
 
 func (e *IncorrectNumberOfPlayersError) Error() string {
-	return fmt.Sprintf("Incorrect number of players. Want between 2 and 6, got: %d", e.NumPlayers)
+	return fmt.Sprintf("Incorrect number of players. Want between %d and %d, got: %d", e.Min, e.Max, e.NumPlayers)
 }
 
 type PlayerIDMustMatchIndexError struct {
@@ -20,3 +23,277 @@ type PlayerIDMustMatchIndexError struct {
 func (e *PlayerIDMustMatchIndexError) Error() string {
 	return fmt.Sprintf("Player with ID %d has index %d which doesn't equal its ID", e.ID, e.Index)
 }
+
+type UnknownPlayerError struct {
+	ID int
+}
+
+func (e *UnknownPlayerError) Error() string {
+	return fmt.Sprintf("Player with ID %d is not playing in this game", e.ID)
+}
+
+type NotYourTurnError struct {
+	PlayerID      int
+	CurrentPlayer int
+}
+
+func (e *NotYourTurnError) Error() string {
+	return fmt.Sprintf("Player %d attempted to act during player %d's turn", e.PlayerID, e.CurrentPlayer)
+}
+
+type WrongPhaseError struct {
+	Phase   Phase
+	Allowed []Phase
+}
+
+func (e *WrongPhaseError) Error() string {
+	return fmt.Sprintf("Action is not allowed during the %q phase, allowed during: %q", e.Phase, e.Allowed)
+}
+
+type GameOverError struct{}
+
+func (e *GameOverError) Error() string {
+	return "The game is over"
+}
+
+type InvalidOptionError struct {
+	Option string
+	Value  string
+}
+
+func (e *InvalidOptionError) Error() string {
+	return fmt.Sprintf("Invalid value %q for game option %q", e.Value, e.Option)
+}
+
+type UnknownTerritoryError struct {
+	Name string
+}
+
+func (e *UnknownTerritoryError) Error() string {
+	return fmt.Sprintf("Territory %q does not exist", e.Name)
+}
+
+type TerritoryNotOwnedError struct {
+	Territory string
+	PlayerID  int
+}
+
+func (e *TerritoryNotOwnedError) Error() string {
+	return fmt.Sprintf("Territory %q is not owned by player %d", e.Territory, e.PlayerID)
+}
+
+type TerritoryAlreadyOwnedError struct {
+	Territory string
+	OwnerID   int
+}
+
+func (e *TerritoryAlreadyOwnedError) Error() string {
+	return fmt.Sprintf("Territory %q is already owned by player %d", e.Territory, e.OwnerID)
+}
+
+type AttackOwnTerritoryError struct {
+	Territory string
+}
+
+func (e *AttackOwnTerritoryError) Error() string {
+	return fmt.Sprintf("Cannot attack territory %q since you already own it", e.Territory)
+}
+
+type UnownedTerritoryError struct {
+	Territory string
+}
+
+func (e *UnownedTerritoryError) Error() string {
+	return fmt.Sprintf("Cannot attack territory %q since nobody owns it", e.Territory)
+}
+
+type TerritoriesNotAdjacentError struct {
+	From string
+	To   string
+}
+
+func (e *TerritoriesNotAdjacentError) Error() string {
+	return fmt.Sprintf("Territory %q does not link to territory %q", e.From, e.To)
+}
+
+type InvalidDiceError struct {
+	Dice int
+	Max  int
+}
+
+func (e *InvalidDiceError) Error() string {
+	return fmt.Sprintf("Invalid number of dice. Want between 1 and %d, got: %d", e.Max, e.Dice)
+}
+
+type InsufficientArmiesError struct {
+	Territory string
+	Armies    int
+	Needed    int
+}
+
+func (e *InsufficientArmiesError) Error() string {
+	return fmt.Sprintf("Territory %q has %d armies but needs at least %d", e.Territory, e.Armies, e.Needed)
+}
+
+type InvalidArmiesError struct {
+	Armies int
+	Min    int
+	Max    int
+}
+
+func (e *InvalidArmiesError) Error() string {
+	return fmt.Sprintf("Invalid number of armies. Want between %d and %d, got: %d", e.Min, e.Max, e.Armies)
+}
+
+type PendingConquestError struct {
+	Territory string
+}
+
+func (e *PendingConquestError) Error() string {
+	return fmt.Sprintf("Must move armies into the conquered territory %q first", e.Territory)
+}
+
+type NoPendingConquestError struct{}
+
+func (e *NoPendingConquestError) Error() string {
+	return "There is no conquered territory to move armies into"
+}
+
+type TooManyArmiesError struct {
+	Armies    int
+	Available int
+}
+
+func (e *TooManyArmiesError) Error() string {
+	return fmt.Sprintf("Cannot place %d armies, only %d left to place", e.Armies, e.Available)
+}
+
+type UnplacedArmiesError struct {
+	Armies int
+}
+
+func (e *UnplacedArmiesError) Error() string {
+	return fmt.Sprintf("Must place the remaining %d armies first", e.Armies)
+}
+
+type InvalidCardSetError struct {
+	Cards []Card
+}
+
+func (e *InvalidCardSetError) Error() string {
+	return fmt.Sprintf("Cards %v do not make a set. Want three of a kind, one of each or a wild card", e.Cards)
+}
+
+type CardNotOwnedError struct {
+	Card     Card
+	PlayerID int
+}
+
+func (e *CardNotOwnedError) Error() string {
+	return fmt.Sprintf("Player %d does not hold the %s card for %q", e.PlayerID, e.Card.ArmyType, e.Card.Territory)
+}
+
+type MustTradeCardsError struct {
+	Cards int
+}
+
+func (e *MustTradeCardsError) Error() string {
+	return fmt.Sprintf("Must trade in cards first, holding %d cards", e.Cards)
+}
+
+type TerritoriesNotConnectedError struct {
+	From string
+	To   string
+}
+
+func (e *TerritoriesNotConnectedError) Error() string {
+	return fmt.Sprintf("Territory %q is not connected to territory %q through your own territories", e.From, e.To)
+}
+
+type NoNeutralPlayerError struct{}
+
+func (e *NoNeutralPlayerError) Error() string {
+	return "There is no neutral player in this game"
+}
+
+type InvalidTeamsError struct {
+	// Teams maps each team ID to the number of players on the team
+	Teams map[int]int
+}
+
+func (e *InvalidTeamsError) Error() string {
+	return fmt.Sprintf("Teams must all have the same number of players and there must be at least two of them, got: %v", e.Teams)
+}
+
+type AttackTeammateError struct {
+	Territory  string
+	TeammateID int
+}
+
+func (e *AttackTeammateError) Error() string {
+	return fmt.Sprintf("Territory %q is owned by teammate %d and can't be attacked", e.Territory, e.TeammateID)
+}
+
+type NotTeammateError struct {
+	PlayerID int
+	OtherID  int
+}
+
+func (e *NotTeammateError) Error() string {
+	return fmt.Sprintf("Player %d is not a teammate of player %d", e.OtherID, e.PlayerID)
+}
+
+type CardTransfersNotAllowedError struct{}
+
+func (e *CardTransfersNotAllowedError) Error() string {
+	return "This game does not allow teammates to transfer cards"
+}
+
+type InvalidMapError struct {
+	Map      string
+	Problems []string
+}
+
+func (e *InvalidMapError) Error() string {
+	return fmt.Sprintf("Map %q is invalid: %s", e.Map, strings.Join(e.Problems, "; "))
+}
+
+type MapImportError struct {
+	Line int
+	Text string
+}
+
+func (e *MapImportError) Error() string {
+	return fmt.Sprintf("Unable to import line %d of the map: %q", e.Line, e.Text)
+}
+
+type InvalidEventError struct {
+	Sequence int
+	Reason   string
+}
+
+func (e *InvalidEventError) Error() string {
+	return fmt.Sprintf("Event %d is invalid: %s", e.Sequence, e.Reason)
+}
+
+type NothingToUndoError struct {
+	PlayerID int
+}
+
+func (e *NothingToUndoError) Error() string {
+	return fmt.Sprintf("Player %d has no action that can be undone", e.PlayerID)
+}
+
+type NoUndoRequestError struct{}
+
+func (e *NoUndoRequestError) Error() string {
+	return "There is no undo request waiting for approval"
+}
+
+type NotUndoApproverError struct {
+	PlayerID int
+}
+
+func (e *NotUndoApproverError) Error() string {
+	return fmt.Sprintf("Player %d does not have a say in the undo request", e.PlayerID)
+}
@@ -0,0 +1,408 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+type EventType string
+
+const (
+	CreatedEvent           EventType = "created"
+	ClaimedEvent           EventType = "claimed"
+	PlacedEvent            EventType = "placed"
+	CapitalDesignatedEvent EventType = "capitalDesignated"
+	NeutralDiceSetEvent    EventType = "neutralDiceSet"
+	ReinforcedEvent        EventType = "reinforced"
+	AttackedEvent          EventType = "attacked"
+	OccupiedEvent          EventType = "occupied"
+	CardsTradedEvent       EventType = "cardsTraded"
+	CardsTransferredEvent  EventType = "cardsTransferred"
+	FortifiedEvent         EventType = "fortified"
+	PhaseEndedEvent        EventType = "phaseEnded"
+	TurnEndedEvent         EventType = "turnEnded"
+	UndoneEvent            EventType = "undone"
+	UndoRequestedEvent     EventType = "undoRequested"
+	UndoApprovedEvent      EventType = "undoApproved"
+	UndoRejectedEvent      EventType = "undoRejected"
+)
+
+// actions builds an empty action of each type of event, for decoding events
+var actions = map[EventType]func() Action{
+	CreatedEvent:           func() Action { return &Created{} },
+	ClaimedEvent:           func() Action { return &Claimed{} },
+	PlacedEvent:            func() Action { return &Placed{} },
+	CapitalDesignatedEvent: func() Action { return &CapitalDesignated{} },
+	NeutralDiceSetEvent:    func() Action { return &NeutralDiceSet{} },
+	ReinforcedEvent:        func() Action { return &Reinforced{} },
+	AttackedEvent:          func() Action { return &Attacked{} },
+	OccupiedEvent:          func() Action { return &Occupied{} },
+	CardsTradedEvent:       func() Action { return &CardsTraded{} },
+	CardsTransferredEvent:  func() Action { return &CardsTransferred{} },
+	FortifiedEvent:         func() Action { return &Fortified{} },
+	PhaseEndedEvent:        func() Action { return &PhaseEnded{} },
+	TurnEndedEvent:         func() Action { return &TurnEnded{} },
+	UndoneEvent:            func() Action { return &Undone{} },
+	UndoRequestedEvent:     func() Action { return &UndoRequested{} },
+	UndoApprovedEvent:      func() Action { return &UndoApproved{} },
+	UndoRejectedEvent:      func() Action { return &UndoRejected{} },
+}
+
+// Type Action is something that happened in a game, which can be applied to the game again to replay it
+type Action interface {
+	Type() EventType
+	apply(g *Game) error
+	// actor returns the ID of the player who took the action, or -1 if no player did
+	actor() int
+}
+
+// Type Event records an action taken in a game
+// Every game starts with a Created event, and folding its events in sequence rebuilds the game
+type Event struct {
+	// Sequence is 1 for the game's first event, 2 for the second and so on
+	Sequence int       `json:"sequence"`
+	Time     time.Time `json:"time"`
+	Action   Action    `json:"action"`
+}
+
+func (e Event) MarshalJSON() ([]byte, error) {
+	type event Event
+	return json.Marshal(struct {
+		Type EventType `json:"type"`
+		event
+	}{e.Action.Type(), event(e)})
+}
+
+func (e *Event) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Sequence int             `json:"sequence"`
+		Time     time.Time       `json:"time"`
+		Type     EventType       `json:"type"`
+		Action   json.RawMessage `json:"action"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	newAction, ok := actions[raw.Type]
+	if !ok {
+		return &InvalidEventError{Sequence: raw.Sequence, Reason: fmt.Sprintf("unknown event type %q", raw.Type)}
+	}
+	action := newAction()
+	if err := json.Unmarshal(raw.Action, action); err != nil {
+		return err
+	}
+	e.Sequence, e.Time, e.Action = raw.Sequence, raw.Time, action
+	return nil
+}
+
+// Type Created starts a new game
+// The seed drives the deal of the territories and cards, so replaying the game deals them out the same way
+// The game keeps its own copy of the map, so it replays on the same board even if the map is changed or removed later
+type Created struct {
+	Name    string   `json:"name"`
+	Players []Player `json:"players"`
+	Options Options  `json:"options"`
+	Map     *Map     `json:"map"`
+	Seed    int64    `json:"seed"`
+}
+
+type Claimed struct {
+	PlayerID  int    `json:"playerId"`
+	Territory string `json:"territory"`
+}
+
+type Placed struct {
+	PlayerID  int    `json:"playerId"`
+	Territory string `json:"territory"`
+}
+
+type CapitalDesignated struct {
+	PlayerID  int    `json:"playerId"`
+	Territory string `json:"territory"`
+}
+
+type NeutralDiceSet struct {
+	PlayerID int `json:"playerId"`
+	Dice     int `json:"dice"`
+}
+
+type Reinforced struct {
+	PlayerID   int            `json:"playerId"`
+	Placements map[string]int `json:"placements"`
+}
+
+// Type Attacked records the dice rolled in an attack, which are rolled again when the attack is replayed
+type Attacked struct {
+	PlayerID      int    `json:"playerId"`
+	From          string `json:"from"`
+	To            string `json:"to"`
+	Dice          int    `json:"dice"`
+	AttackerRolls []int  `json:"attackerRolls"`
+	DefenderRolls []int  `json:"defenderRolls"`
+}
+
+type Occupied struct {
+	PlayerID int `json:"playerId"`
+	Armies   int `json:"armies"`
+}
+
+type CardsTraded struct {
+	PlayerID int    `json:"playerId"`
+	Cards    []Card `json:"cards"`
+}
+
+type CardsTransferred struct {
+	PlayerID   int    `json:"playerId"`
+	TeammateID int    `json:"teammateId"`
+	Cards      []Card `json:"cards"`
+}
+
+type Fortified struct {
+	PlayerID int    `json:"playerId"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Armies   int    `json:"armies"`
+}
+
+type PhaseEnded struct {
+	PlayerID int `json:"playerId"`
+}
+
+type TurnEnded struct {
+	PlayerID int `json:"playerId"`
+}
+
+// Type Undone undoes the player's last action
+type Undone struct {
+	PlayerID int `json:"playerId"`
+}
+
+// Type UndoRequested asks the other players to approve undoing the player's last action
+type UndoRequested struct {
+	PlayerID int `json:"playerId"`
+}
+
+type UndoApproved struct {
+	PlayerID int `json:"playerId"`
+}
+
+type UndoRejected struct {
+	PlayerID int `json:"playerId"`
+}
+
+func (*Created) Type() EventType           { return CreatedEvent }
+func (*Claimed) Type() EventType           { return ClaimedEvent }
+func (*Placed) Type() EventType            { return PlacedEvent }
+func (*CapitalDesignated) Type() EventType { return CapitalDesignatedEvent }
+func (*NeutralDiceSet) Type() EventType    { return NeutralDiceSetEvent }
+func (*Reinforced) Type() EventType        { return ReinforcedEvent }
+func (*Attacked) Type() EventType          { return AttackedEvent }
+func (*Occupied) Type() EventType          { return OccupiedEvent }
+func (*CardsTraded) Type() EventType       { return CardsTradedEvent }
+func (*CardsTransferred) Type() EventType  { return CardsTransferredEvent }
+func (*Fortified) Type() EventType         { return FortifiedEvent }
+func (*PhaseEnded) Type() EventType        { return PhaseEndedEvent }
+func (*TurnEnded) Type() EventType         { return TurnEndedEvent }
+func (*Undone) Type() EventType            { return UndoneEvent }
+func (*UndoRequested) Type() EventType     { return UndoRequestedEvent }
+func (*UndoApproved) Type() EventType      { return UndoApprovedEvent }
+func (*UndoRejected) Type() EventType      { return UndoRejectedEvent }
+
+func (*Created) actor() int             { return -1 }
+func (a *Claimed) actor() int           { return a.PlayerID }
+func (a *Placed) actor() int            { return a.PlayerID }
+func (a *CapitalDesignated) actor() int { return a.PlayerID }
+func (a *NeutralDiceSet) actor() int    { return a.PlayerID }
+func (a *Reinforced) actor() int        { return a.PlayerID }
+func (a *Attacked) actor() int          { return a.PlayerID }
+func (a *Occupied) actor() int          { return a.PlayerID }
+func (a *CardsTraded) actor() int       { return a.PlayerID }
+func (a *CardsTransferred) actor() int  { return a.PlayerID }
+func (a *Fortified) actor() int         { return a.PlayerID }
+func (a *PhaseEnded) actor() int        { return a.PlayerID }
+func (a *TurnEnded) actor() int         { return a.PlayerID }
+func (a *Undone) actor() int            { return a.PlayerID }
+func (a *UndoRequested) actor() int     { return a.PlayerID }
+func (a *UndoApproved) actor() int      { return a.PlayerID }
+func (a *UndoRejected) actor() int      { return a.PlayerID }
+
+// A game is only ever created once, by its first event
+func (a *Created) apply(g *Game) error {
+	return &InvalidEventError{Reason: "a game can only be created by its first event"}
+}
+
+func (a *Claimed) apply(g *Game) error {
+	return g.Claim(a.PlayerID, a.Territory)
+}
+
+func (a *Placed) apply(g *Game) error {
+	return g.Place(a.PlayerID, a.Territory)
+}
+
+func (a *CapitalDesignated) apply(g *Game) error {
+	return g.DesignateCapital(a.PlayerID, a.Territory)
+}
+
+func (a *NeutralDiceSet) apply(g *Game) error {
+	return g.SetNeutralDice(a.PlayerID, a.Dice)
+}
+
+func (a *Reinforced) apply(g *Game) error {
+	return g.PlaceReinforcements(a.PlayerID, a.Placements)
+}
+
+// The attack is replayed with the dice that were rolled the first time round
+func (a *Attacked) apply(g *Game) error {
+	dice := g.dice
+	defer g.SetDice(dice)
+	g.SetDice(&loadedDice{rolls: append(append([]int{}, a.AttackerRolls...), a.DefenderRolls...)})
+	_, err := g.Attack(a.PlayerID, a.From, a.To, a.Dice)
+	return err
+}
+
+func (a *Occupied) apply(g *Game) error {
+	return g.Occupy(a.PlayerID, a.Armies)
+}
+
+func (a *CardsTraded) apply(g *Game) error {
+	_, err := g.TradeCards(a.PlayerID, a.Cards)
+	return err
+}
+
+func (a *CardsTransferred) apply(g *Game) error {
+	return g.TransferCards(a.PlayerID, a.TeammateID, a.Cards)
+}
+
+func (a *Fortified) apply(g *Game) error {
+	return g.Fortify(a.PlayerID, a.From, a.To, a.Armies)
+}
+
+func (a *PhaseEnded) apply(g *Game) error {
+	return g.EndPhase(a.PlayerID)
+}
+
+func (a *TurnEnded) apply(g *Game) error {
+	return g.EndTurn(a.PlayerID)
+}
+
+// Undoing replays the same way it happened the first time, either undoing the action or requesting the undo
+func (a *Undone) apply(g *Game) error {
+	return g.Undo(a.PlayerID)
+}
+
+func (a *UndoRequested) apply(g *Game) error {
+	return g.Undo(a.PlayerID)
+}
+
+func (a *UndoApproved) apply(g *Game) error {
+	return g.ApproveUndo(a.PlayerID)
+}
+
+func (a *UndoRejected) apply(g *Game) error {
+	return g.RejectUndo(a.PlayerID)
+}
+
+// undoableEvents returns the sequence numbers of the events that can be undone at some point in the game:
+// those in a run of actions by the same player that's later undone, and those in the last run, which the player
+// can still undo
+// Replaying a game only keeps the state before these events, rather than before every event
+func undoableEvents(events []Event) map[int]bool {
+	undoable := make(map[int]bool)
+	run, actor := []int{}, -1
+	for _, e := range events {
+		switch e.Action.(type) {
+		case *Created:
+		case *Undone, *UndoRequested, *UndoApproved, *UndoRejected:
+			for _, sequence := range run {
+				undoable[sequence] = true
+			}
+		default:
+			if e.Action.actor() != actor {
+				run, actor = []int{}, e.Action.actor()
+			}
+			run = append(run, e.Sequence)
+		}
+	}
+	for _, sequence := range run {
+		undoable[sequence] = true
+	}
+	return undoable
+}
+
+// loadedDice rolls a fixed sequence of dice
+// Sorting the rolls of each side doesn't change them, so recorded rolls come out as they went in
+type loadedDice struct {
+	rolls []int
+}
+
+func (d *loadedDice) Roll() int {
+	if len(d.rolls) == 0 {
+		return 1
+	}
+	roll := d.rolls[0]
+	d.rolls = d.rolls[1:]
+	return roll
+}
+
+// record adds an event for the action to the game's events
+// Actions are recorded before they change the game, so that the game before the action can be kept for undoing it
+// Nothing is recorded while the game is being replayed, since the replay already has its events
+func (g *Game) record(action Action) {
+	switch action.(type) {
+	case *Undone, *UndoRequested, *UndoApproved, *UndoRejected:
+		g.UndoRequest = nil
+	default:
+		g.saveUndoPoint(action)
+	}
+	if g.replaying {
+		return
+	}
+	g.Sequence++
+	g.events = append(g.events, Event{
+		Sequence: g.Sequence,
+		Time:     time.Now().UTC(),
+		Action:   action,
+	})
+}
+
+// Events returns the game's events, starting with the event after the given sequence number
+// Passing 0 returns every event since the game was created
+func (g *Game) Events(after int) []Event {
+	i := sort.Search(len(g.events), func(i int) bool { return g.events[i].Sequence > after })
+	return append([]Event{}, g.events[i:]...)
+}
+
+// Replay rebuilds a game by folding its events, in sequence, into the game they created
+func Replay(events []Event) (*Game, error) {
+	if len(events) == 0 {
+		return nil, &InvalidEventError{Reason: "a game needs a created event"}
+	}
+	created, ok := events[0].Action.(*Created)
+	if !ok {
+		return nil, &InvalidEventError{Sequence: events[0].Sequence, Reason: "a game must start with a created event"}
+	}
+	g, err := newGame(created.Name, created.Players, created.Options, created.Map, created.Seed)
+	if err != nil {
+		return nil, &InvalidEventError{Sequence: events[0].Sequence, Reason: err.Error()}
+	}
+
+	g.replaying = true
+	g.undoable = undoableEvents(events)
+	defer func() { g.replaying, g.undoable = false, nil }()
+	g.events = []Event{}
+	for i, e := range events {
+		if e.Sequence != i+1 {
+			return nil, &InvalidEventError{Sequence: e.Sequence, Reason: fmt.Sprintf("expected event %d", i+1)}
+		}
+		if i > 0 {
+			if err := e.Action.apply(g); err != nil {
+				return nil, &InvalidEventError{Sequence: e.Sequence, Reason: err.Error()}
+			}
+		}
+		g.events = append(g.events, e)
+		g.Sequence = e.Sequence
+	}
+	return g, nil
+}
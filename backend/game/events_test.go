@@ -0,0 +1,273 @@
+package game
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// sortedTerritories returns the names of the game's territories in order
+func sortedTerritories(game *Game) []string {
+	names := []string{}
+	for name := range game.Territories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// tradeAnySet has the player trade in the first valid set of cards in their hand
+func tradeAnySet(t *testing.T, game *Game, playerID int) {
+	hand := game.Cards.OwnedBy[playerID]
+	for i := range hand {
+		for j := i + 1; j < len(hand); j++ {
+			for k := j + 1; k < len(hand); k++ {
+				set := []Card{hand[i], hand[j], hand[k]}
+				if IsValidSet(set) {
+					if _, err := game.TradeCards(playerID, set); err != nil {
+						t.Fatal("Unexpected error trading cards:", err)
+					}
+					return
+				}
+			}
+		}
+	}
+	t.Fatalf("Player %d has no set of cards to trade: %v", playerID, hand)
+}
+
+// playGame plays the game until it is over or the given turn has been reached
+// Each player piles their armies onto their first territory that borders an enemy and attacks from there
+func playGame(t *testing.T, game *Game, turns int) {
+	attacks := 0
+	for game.Phase != GameOverPhase && game.TurnNumber <= turns {
+		p := game.CurrentPlayer
+		var err error
+		switch game.Phase {
+		case ClaimPhase:
+			for _, name := range sortedTerritories(game) {
+				if game.Territories[name].OwnedBy == nil {
+					err = game.Claim(p, name)
+					break
+				}
+			}
+		case PlacementPhase:
+			err = game.Place(p, frontLine(game, p))
+		case CapitalPhase:
+			err = game.DesignateCapital(p, frontLine(game, p))
+		case ReinforcePhase, AttackPhase:
+			var mustTrade *MustTradeCardsError
+			if errors.As(game.checkHandSize(p), &mustTrade) {
+				tradeAnySet(t, game, p)
+			} else if game.Reserves[p] > 0 {
+				err = game.PlaceReinforcements(p, map[string]int{frontLine(game, p): game.Reserves[p]})
+			} else if game.Phase == ReinforcePhase {
+				err = game.EndPhase(p)
+			} else if game.PendingConquest != nil {
+				err = game.Occupy(p, game.PendingConquest.MaxArmies)
+			} else if from, to := target(game, p); from != "" && attacks < 20 {
+				attacks++
+				dice := game.Territories[from].Strength() - 1
+				if dice > 3 {
+					dice = 3
+				}
+				_, err = game.Attack(p, from, to, dice)
+			} else {
+				attacks = 0
+				err = game.EndPhase(p)
+			}
+		case FortifyPhase:
+			from := frontLine(game, p)
+			for _, to := range game.Territories[from].Links {
+				if game.Territories[to].isOwnedBy(p) && game.Territories[from].Strength() > 1 {
+					err = game.Fortify(p, from, to, 1)
+					break
+				}
+			}
+			if game.CurrentPlayer == p && game.Phase == FortifyPhase && err == nil {
+				err = game.EndTurn(p)
+			}
+		}
+		if err != nil {
+			t.Fatalf("Unexpected error playing the %q phase: %s", game.Phase, err)
+		}
+	}
+}
+
+// frontLine returns the player's first territory that borders an enemy, or their first territory if none do
+func frontLine(game *Game, playerID int) string {
+	first := ""
+	for _, name := range sortedTerritories(game) {
+		territory := game.Territories[name]
+		if !territory.isOwnedBy(playerID) {
+			continue
+		}
+		if first == "" {
+			first = name
+		}
+		for _, link := range territory.Links {
+			if !game.Territories[link].isOwnedBy(playerID) {
+				return name
+			}
+		}
+	}
+	return first
+}
+
+// target returns a territory the player can attack, and the territory to attack it from
+func target(game *Game, playerID int) (string, string) {
+	for _, name := range sortedTerritories(game) {
+		territory := game.Territories[name]
+		if !territory.isOwnedBy(playerID) || territory.Strength() < 2 {
+			continue
+		}
+		for _, link := range territory.Links {
+			if !game.Territories[link].isOwnedBy(playerID) {
+				return name, link
+			}
+		}
+	}
+	return "", ""
+}
+
+func TestReplay(t *testing.T) {
+	testCases := []struct {
+		name    string
+		options Options
+	}{
+		{"Classic", Options{}},
+		{"Draft", Options{Setup: DraftSetup}},
+		{"SecretMission", Options{Mode: SecretMission}},
+		{"CapitalRisk", Options{Mode: CapitalRisk}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			game := newTestGame(t, tc.options)
+			playGame(t, game, 30)
+
+			events := game.Events(0)
+			if len(events) == 0 || events[0].Action.Type() != CreatedEvent || game.Sequence != len(events) {
+				t.Fatalf("Expected the game's events to start with its creation, got %d events", len(events))
+			}
+			for i, e := range events {
+				if e.Sequence != i+1 || e.Time.IsZero() {
+					t.Errorf("Expected event %d to be numbered and timestamped, got: %v", i+1, e)
+				}
+			}
+
+			// The events survive being stored as JSON
+			data, err := json.Marshal(events)
+			if err != nil {
+				t.Fatal("Unexpected error encoding the events:", err)
+			}
+			var decoded []Event
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatal("Unexpected error decoding the events:", err)
+			}
+
+			replayed, err := Replay(decoded)
+			if err != nil {
+				t.Fatal("Unexpected error replaying the game:", err)
+			}
+			want, _ := json.Marshal(game)
+			got, _ := json.Marshal(replayed)
+			if !bytes.Equal(got, want) {
+				t.Errorf("Expected the replay to rebuild the game.\nGot:  %s\nWant: %s", got, want)
+			}
+			if len(replayed.Events(0)) != len(events) {
+				t.Errorf("Expected the replayed game to keep its %d events, got: %d", len(events), len(replayed.Events(0)))
+			}
+		})
+	}
+}
+
+func TestEventsAfter(t *testing.T) {
+	game := newTestGame(t, Options{})
+	placeAllArmies(t, game)
+
+	after := game.Events(10)
+	if len(after) != game.Sequence-10 || after[0].Sequence != 11 {
+		t.Errorf("Expected the events after the 10th, got %d starting at %d", len(after), after[0].Sequence)
+	}
+	if _, ok := after[0].Action.(*Placed); !ok {
+		t.Errorf("Expected a placement, got: %T", after[0].Action)
+	}
+	if events := game.Events(game.Sequence); len(events) != 0 {
+		t.Errorf("Expected no events after the last, got: %d", len(events))
+	}
+
+	// Failed actions aren't recorded
+	sequence := game.Sequence
+	game.EndTurn(1)
+	if game.Sequence != sequence {
+		t.Errorf("Expected a failed action to leave the events alone, got sequence %d", game.Sequence)
+	}
+}
+
+func TestReplayChangedMap(t *testing.T) {
+	m, err := LoadMap(strings.NewReader(testMap))
+	if err != nil {
+		t.Fatal("Unexpected error loading the map:", err)
+	}
+	m.Name = "shifting"
+	RegisterMap(m)
+	game := newTestGame(t, Options{Map: "shifting"})
+
+	// The game replays on the board it was created on, whatever is registered under the map's name since
+	changed, _ := LoadMap(strings.NewReader(testMap))
+	changed.Name = "shifting"
+	changed.Territories = changed.Territories[:3]
+	RegisterMap(changed)
+
+	data, err := json.Marshal(game.Events(0))
+	if err != nil {
+		t.Fatal("Unexpected error encoding the events:", err)
+	}
+	var events []Event
+	if err := json.Unmarshal(data, &events); err != nil {
+		t.Fatal("Unexpected error decoding the events:", err)
+	}
+	replayed, err := Replay(events)
+	if err != nil {
+		t.Fatal("Unexpected error replaying the game:", err)
+	}
+	if len(replayed.Territories) != 6 || len(replayed.Map().Territories) != 6 {
+		t.Errorf("Expected the game to replay on its own 6 territories, got: %d", len(replayed.Territories))
+	}
+}
+
+func TestReplayInvalidEvents(t *testing.T) {
+	game := newTestGame(t, Options{})
+	placeAllArmies(t, game)
+	events := game.Events(0)
+
+	testCases := []struct {
+		name   string
+		events []Event
+	}{
+		{"NoEvents", []Event{}},
+		{"NotCreated", events[1:]},
+		{"OutOfSequence", append([]Event{events[0]}, events[2:]...)},
+		{"CreatedTwice", append([]Event{events[0]}, Event{Sequence: 2, Action: events[0].Action})},
+		{"IllegalAction", []Event{events[0], Event{Sequence: 2, Action: &TurnEnded{PlayerID: 1}}}},
+		{"NoMap", []Event{{Sequence: 1, Action: &Created{Name: "Lost", Players: game.Players, Options: game.Options}}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var invalid *InvalidEventError
+			if _, err := Replay(tc.events); !errors.As(err, &invalid) {
+				t.Errorf("Expected an InvalidEventError, got: %v", err)
+			}
+		})
+	}
+
+	var invalid *InvalidEventError
+	var e Event
+	if err := json.Unmarshal([]byte(`{"sequence":2,"type":"surrendered","action":{}}`), &e); !errors.As(err, &invalid) {
+		t.Errorf("Expected an InvalidEventError decoding an unknown event, got: %v", err)
+	}
+}
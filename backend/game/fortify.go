@@ -0,0 +1,90 @@
+package game
+
+// Fortify moves armies between two of the player's territories at the end of their turn
+// Which territories armies can move between, and how many moves the player gets, depends on the game's fortify rule
+// Unless the rule allows unlimited moves, fortifying ends the player's turn
+func (g *Game) Fortify(playerID int, from, to string, armies int) error {
+	if err := g.checkTurn(playerID, AttackPhase, FortifyPhase); err != nil {
+		return err
+	}
+	if err := g.checkNoPendingConquest(); err != nil {
+		return err
+	}
+	if err := g.checkHandSize(playerID); err != nil {
+		return err
+	}
+	if err := g.checkReinforcementsPlaced(playerID); err != nil {
+		return err
+	}
+
+	source, ok := g.Territories[from]
+	if !ok {
+		return &UnknownTerritoryError{Name: from}
+	}
+	destination, ok := g.Territories[to]
+	if !ok {
+		return &UnknownTerritoryError{Name: to}
+	}
+	if !source.isOwnedBy(playerID) {
+		return &TerritoryNotOwnedError{Territory: from, PlayerID: playerID}
+	}
+	if !destination.isOwnedBy(playerID) {
+		return &TerritoryNotOwnedError{Territory: to, PlayerID: playerID}
+	}
+
+	switch g.Options.Fortify {
+	case AdjacentFortify:
+		if !source.linksTo(to) {
+			return &TerritoriesNotAdjacentError{From: from, To: to}
+		}
+	default:
+		if from == to || !g.connected(playerID, from, to) {
+			return &TerritoriesNotConnectedError{From: from, To: to}
+		}
+	}
+
+	// At least one army must always be left behind
+	if armies < 1 || armies >= source.Strength() {
+		return &InvalidArmiesError{Armies: armies, Min: 1, Max: source.Strength() - 1}
+	}
+
+	g.record(&Fortified{PlayerID: playerID, From: from, To: to, Armies: armies})
+	if err := source.moveArmies(destination, armies); err != nil {
+		return err
+	}
+
+	g.checkVictory()
+	if g.Phase == GameOverPhase {
+		return nil
+	}
+
+	if g.Options.Fortify == UnlimitedFortify {
+		g.Phase = FortifyPhase
+		return nil
+	}
+	g.nextTurn()
+	return nil
+}
+
+// connected reports whether there is a path between the two territories passing only through the player's own territories,
+// or those of their teammates
+func (g *Game) connected(playerID int, from, to string) bool {
+	visited := map[string]bool{from: true}
+	queue := []string{from}
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		if name == to {
+			return true
+		}
+		for _, link := range g.Territories[name].Links {
+			t, ok := g.Territories[link]
+			if !ok || visited[link] || !g.isAllied(t, playerID) {
+				continue
+			}
+			visited[link] = true
+			queue = append(queue, link)
+		}
+	}
+	return false
+}
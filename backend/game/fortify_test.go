@@ -0,0 +1,110 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+// newFortifyGame returns a game in player 0's attack phase, with player 0 owning a chain of territories
+// from Alaska through to Quebec as well as Peru, and player 1 owning every other territory
+func newFortifyGame(t *testing.T, rule FortifyRule) *Game {
+	game := newTestGame(t, Options{Fortify: rule})
+	giveTerritories(game, "Alaska", "Alberta", "Ontario", "Quebec", "Peru")
+	game.beginTurns()
+	game.Reserves = map[int]int{0: 0, 1: 0, 2: 0}
+	game.Phase = AttackPhase
+	for _, territory := range game.Territories {
+		territory.Armies = map[Army]int{Infantry: 1, Cavalry: 0, Artillery: 0}
+	}
+	game.Territories["Alaska"].Armies[Infantry] = 5
+	return game
+}
+
+func TestFortifyAdjacent(t *testing.T) {
+	game := newFortifyGame(t, AdjacentFortify)
+
+	var notAdjacent *TerritoriesNotAdjacentError
+	if err := game.Fortify(0, "Alaska", "Ontario", 1); !errors.As(err, &notAdjacent) {
+		t.Errorf("Expected a TerritoriesNotAdjacentError fortifying Ontario from Alaska, got: %v", err)
+	}
+
+	if err := game.Fortify(0, "Alaska", "Alberta", 2); err != nil {
+		t.Fatal("Unexpected error fortifying Alberta:", err)
+	}
+	if game.Territories["Alaska"].Strength() != 3 || game.Territories["Alberta"].Strength() != 3 {
+		t.Errorf("Expected Alaska and Alberta to have 3 armies each, got: %d and %d", game.Territories["Alaska"].Strength(), game.Territories["Alberta"].Strength())
+	}
+	if game.CurrentPlayer != 1 || game.Phase != ReinforcePhase {
+		t.Errorf("Expected fortifying to end the turn, got: player %d in the %q phase", game.CurrentPlayer, game.Phase)
+	}
+}
+
+func TestFortifyConnected(t *testing.T) {
+	game := newFortifyGame(t, ConnectedFortify)
+
+	var notOwned *TerritoryNotOwnedError
+	if err := game.Fortify(0, "Alaska", "Greenland", 1); !errors.As(err, &notOwned) {
+		t.Errorf("Expected a TerritoryNotOwnedError fortifying another player's territory, got: %v", err)
+	}
+	var notConnected *TerritoriesNotConnectedError
+	if err := game.Fortify(0, "Alaska", "Peru", 1); !errors.As(err, &notConnected) {
+		t.Errorf("Expected a TerritoriesNotConnectedError fortifying Peru from Alaska, got: %v", err)
+	}
+	if err := game.Fortify(0, "Alaska", "Alaska", 1); !errors.As(err, &notConnected) {
+		t.Errorf("Expected a TerritoriesNotConnectedError fortifying Alaska from itself, got: %v", err)
+	}
+	var invalidArmies *InvalidArmiesError
+	if err := game.Fortify(0, "Alaska", "Quebec", 5); !errors.As(err, &invalidArmies) {
+		t.Errorf("Expected an InvalidArmiesError moving every army out of Alaska, got: %v", err)
+	}
+	if err := game.Fortify(0, "Alaska", "Quebec", 0); !errors.As(err, &invalidArmies) {
+		t.Errorf("Expected an InvalidArmiesError moving no armies, got: %v", err)
+	}
+
+	if err := game.Fortify(0, "Alaska", "Quebec", 4); err != nil {
+		t.Fatal("Unexpected error fortifying Quebec:", err)
+	}
+	if game.Territories["Alaska"].Strength() != 1 || game.Territories["Quebec"].Strength() != 5 {
+		t.Errorf("Expected Alaska and Quebec to have 1 and 5 armies, got: %d and %d", game.Territories["Alaska"].Strength(), game.Territories["Quebec"].Strength())
+	}
+	if game.CurrentPlayer != 1 {
+		t.Errorf("Expected fortifying to end the turn, got: player %d", game.CurrentPlayer)
+	}
+}
+
+func TestFortifyUnlimited(t *testing.T) {
+	game := newFortifyGame(t, UnlimitedFortify)
+
+	if err := game.Fortify(0, "Alaska", "Quebec", 2); err != nil {
+		t.Fatal("Unexpected error fortifying Quebec:", err)
+	}
+	if game.CurrentPlayer != 0 || game.Phase != FortifyPhase {
+		t.Fatalf("Expected player 0 to keep fortifying, got: player %d in the %q phase", game.CurrentPlayer, game.Phase)
+	}
+	if err := game.Fortify(0, "Quebec", "Alberta", 1); err != nil {
+		t.Fatal("Unexpected error fortifying Alberta:", err)
+	}
+	if game.Territories["Alaska"].Strength() != 3 || game.Territories["Quebec"].Strength() != 2 || game.Territories["Alberta"].Strength() != 2 {
+		t.Errorf("Expected Alaska, Quebec and Alberta to have 3, 2 and 2 armies, got: %d, %d and %d", game.Territories["Alaska"].Strength(), game.Territories["Quebec"].Strength(), game.Territories["Alberta"].Strength())
+	}
+
+	var wrongPhase *WrongPhaseError
+	if _, err := game.Attack(0, "Alaska", "Kamchatka", 1); !errors.As(err, &wrongPhase) {
+		t.Errorf("Expected a WrongPhaseError attacking after fortifying, got: %v", err)
+	}
+	if err := game.EndTurn(0); err != nil {
+		t.Error("Unexpected error ending the turn:", err)
+	}
+}
+
+func TestInvalidFortifyRule(t *testing.T) {
+	players := []Player{
+		Player{ID: 0, Name: "Zero"},
+		Player{ID: 1, Name: "One"},
+		Player{ID: 2, Name: "Two"},
+	}
+	var invalidOption *InvalidOptionError
+	if _, err := NewGame("Test Game", players, Options{Fortify: "teleport"}); !errors.As(err, &invalidOption) {
+		t.Errorf("Expected an InvalidOptionError for an unknown fortify rule, got: %v", err)
+	}
+}
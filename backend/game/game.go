@@ -1,5 +1,13 @@
 package game
 
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+)
+
 type Army int
 
 const (
@@ -26,11 +34,61 @@ func (army Army) String() string {
 }
 
 type Game struct {
+	// ID identifies the game in the store holding it
+	ID            string                  `json:"id"`
 	Name          string                  `json:"name"`
 	GoldenCavalry int                     `json:"goldenCavalry"`
 	Territories   map[string](*Territory) `json:"territories"`
 	Cards         *Cards                  `json:"cards"`
 	Players       []Player                `json:"players"`
+	Phase         Phase                   `json:"phase"`
+	CurrentPlayer int                     `json:"currentPlayer"`
+	TurnNumber    int                     `json:"turnNumber"`
+	Options       Options                 `json:"options"`
+
+	// Continents holds the bonus for owning each continent on the game's map, keyed by continent name
+	Continents map[string]int `json:"continents"`
+
+	// Reserves holds the number of armies each player has yet to place on the board, keyed by player id
+	Reserves       map[int]int `json:"reserves"`
+	PlacementRound int         `json:"placementRound"`
+
+	// Reinforcement breaks down the armies the current player received at the start of their turn
+	Reinforcement *Reinforcement `json:"reinforcement"`
+
+	// PendingConquest is set when the current player has conquered a territory but not yet moved into it
+	PendingConquest   *Conquest `json:"pendingConquest"`
+	ConqueredThisTurn bool      `json:"conqueredThisTurn"`
+	// ForcedTrade is set when the current player must trade in cards taken from a player they eliminated
+	ForcedTrade bool `json:"forcedTrade"`
+
+	// Eliminations lists the players knocked out of the game, in the order they were eliminated
+	Eliminations []Elimination `json:"eliminations"`
+	// Winners holds the IDs of the players who won, once the game is over
+	Winners []int `json:"winners"`
+
+	// Missions holds each player's secret mission in a secret mission game, keyed by player id
+	Missions map[int]*Mission `json:"missions"`
+	// Capitals holds the name of each player's capital in a capital risk game, keyed by player id
+	Capitals map[int]string `json:"capitals"`
+	// NeutralDice holds the number of dice each player rolls for the neutral player when their opponent attacks it,
+	// keyed by player id
+	NeutralDice map[int]int `json:"neutralDice"`
+
+	// Sequence is the sequence number of the last event recorded in the game
+	Sequence int `json:"sequence"`
+	// UndoRequest is set while a player waits for the other players to approve undoing their last action
+	UndoRequest *UndoRequest `json:"undoRequest"`
+
+	rng        *rand.Rand
+	dice       Dice
+	events     []Event
+	replaying  bool
+	undoPoints []undoPoint
+	// board is the map the game is played on
+	board *Map
+	// undoable holds the sequence numbers of the events whose undo points are kept while the game is replayed
+	undoable map[int]bool
 }
 
 type Territory struct {
@@ -41,6 +99,19 @@ type Territory struct {
 	Armies    map[Army]int `json:"armies"`
 }
 
+func (t *Territory) isOwnedBy(playerID int) bool {
+	return t.OwnedBy != nil && t.OwnedBy.ID == playerID
+}
+
+func (t *Territory) linksTo(name string) bool {
+	for _, link := range t.Links {
+		if link == name {
+			return true
+		}
+	}
+	return false
+}
+
 type Cards struct {
 	DrawPile    []Card         `json:"drawPile"`
 	DiscardPile []Card         `json:"discardPile"`
@@ -55,22 +126,59 @@ type Card struct {
 type Player struct {
 	ID   int    `json:"id"`
 	Name string `json:"name"`
+	// Neutral is set for the neutral player of a two-player game, who never takes a turn
+	Neutral bool `json:"neutral"`
+	// TeamID is the team the player plays for in a team game
+	TeamID int `json:"teamId"`
+}
+
+func NewGame(name string, players []Player, options Options) (*Game, error) {
+	seed := newSeed()
+	m, _ := LookupMap(options.withDefaults().Map)
+	g, err := newGame(name, players, options, m, seed)
+	if err != nil {
+		return nil, err
+	}
+	g.record(&Created{
+		Name:    name,
+		Players: append([]Player{}, players...),
+		Options: options,
+		Map:     m,
+		Seed:    seed,
+	})
+	return g, nil
 }
 
-func NewGame(name string, players []Player) (*Game, error) {
-	// We are playing "World Domination Risk" which requires 3-6 players
-	if len(players) < 3 || len(players) > 6 {
-		return nil, &IncorrectNumberOfPlayersError{NumPlayers: len(players)}
+// newGame creates a game on the map whose deal is driven by the seed
+func newGame(name string, players []Player, options Options, m *Map, seed int64) (*Game, error) {
+	if err := options.validate(); err != nil {
+		return nil, err
+	}
+	if m == nil {
+		return nil, &InvalidOptionError{Option: "map", Value: options.Map}
+	}
+
+	// The standard game requires 3-6 players, while the two-player game adds a neutral third player
+	min, max := options.playerLimits()
+	if len(players) < min || len(players) > max {
+		return nil, &IncorrectNumberOfPlayersError{NumPlayers: len(players), Min: min, Max: max}
+	}
+
+	if options.Teams {
+		if err := validateTeams(players); err != nil {
+			return nil, err
+		}
 	}
 
-	// Initialize the draw pile of cards
-	// We'll append to this as we initialize the territories, but there are two wild cards in the deck
-	var card Card
-	drawPile := []Card{
-		Card{Territory: "Myjäss", ArmyType: Wild},
-		Card{Territory: "Myjäss", ArmyType: Wild},
+	// Territories keep pointers to the players that own them, so we take our own copy of the players
+	players = append([]Player{}, players...)
+	if options.TwoPlayer {
+		players = append(players, Player{ID: len(players), Name: "Neutral", Neutral: true})
 	}
 
+	// Initialize the draw pile of cards from the map's deck
+	drawPile := append([]Card{}, m.Cards...)
+
 	// Initialize the empty discard pile
 	discardPile := []Card{}
 
@@ -85,397 +193,11 @@ func NewGame(name string, players []Player) (*Game, error) {
 		ownedBy[i] = []Card{}
 	}
 
-	// Initialize all 42 territories
-	territories := make(map[string](*Territory))
-
-	// North America
-	card = Card{Territory: "Alaska", ArmyType: Infantry}
-	drawPile = append(drawPile, card)
-	territories["Alaska"] = &Territory{
-		Name:      "Alaska",
-		Continent: "North America",
-		Links:     []string{"Alberta", "Northwest Territory", "Kamchatka"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-	card = Card{Territory: "Alberta", ArmyType: Cavalry}
-	drawPile = append(drawPile, card)
-	territories["Alberta"] = &Territory{
-		Name:      "Alberta",
-		Continent: "North America",
-		Links:     []string{"Alaska", "Northwest Territory", "Ontario", "Western United States"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-	card = Card{Territory: "Western United States", ArmyType: Artillery}
-	drawPile = append(drawPile, card)
-	territories["Western United States"] = &Territory{
-		Name:      "Western United States",
-		Continent: "North America",
-		Links:     []string{"Alberta", "Ontario", "Eastern United States", "Central America"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-	card = Card{Territory: "Central America", ArmyType: Infantry}
-	drawPile = append(drawPile, card)
-	territories["Central America"] = &Territory{
-		Name:      "Central America",
-		Continent: "North America",
-		Links:     []string{"Western United States", "Eastern United States", "Venezuela"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-	card = Card{Territory: "Northwest Territory", ArmyType: Cavalry}
-	drawPile = append(drawPile, card)
-	territories["Northwest Territory"] = &Territory{
-		Name:      "Northwest Territory",
-		Continent: "North America",
-		Links:     []string{"Alaska", "Alberta", "Ontario", "Greenland"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-	card = Card{Territory: "Ontario", ArmyType: Artillery}
-	drawPile = append(drawPile, card)
-	territories["Ontario"] = &Territory{
-		Name:      "Ontario",
-		Continent: "North America",
-		Links:     []string{"Northwest Territory", "Alberta", "Western United States", "Eastern United States", "Quebec", "Greenland"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-	card = Card{Territory: "Eastern United States", ArmyType: Infantry}
-	drawPile = append(drawPile, card)
-	territories["Eastern United States"] = &Territory{
-		Name:      "Eastern United States",
-		Continent: "North America",
-		Links:     []string{"Ontario", "Western United States", "Central America", "Quebec"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-	card = Card{Territory: "Greenland", ArmyType: Cavalry}
-	drawPile = append(drawPile, card)
-	territories["Greenland"] = &Territory{
-		Name:      "Greenland",
-		Continent: "North America",
-		Links:     []string{"Northwest Territory", "Ontario", "Quebec", "Iceland"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-	card = Card{Territory: "Quebec", ArmyType: Artillery}
-	drawPile = append(drawPile, card)
-	territories["Quebec"] = &Territory{
-		Name:      "Quebec",
-		Continent: "North America",
-		Links:     []string{"Greenland", "Ontario", "Eastern United States"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-
-	// South America
-	card = Card{Territory: "Venezuela", ArmyType: Infantry}
-	drawPile = append(drawPile, card)
-	territories["Venezuela"] = &Territory{
-		Name:      "Venezuela",
-		Continent: "South America",
-		Links:     []string{"Central America", "Peru", "Brazil"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-	card = Card{Territory: "Peru", ArmyType: Cavalry}
-	drawPile = append(drawPile, card)
-	territories["Peru"] = &Territory{
-		Name:      "Peru",
-		Continent: "South America",
-		Links:     []string{"Venezuela", "Argentina", "Brazil"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-	card = Card{Territory: "Argentina", ArmyType: Artillery}
-	drawPile = append(drawPile, card)
-	territories["Argentina"] = &Territory{
-		Name:      "Argentina",
-		Continent: "South America",
-		Links:     []string{"Peru", "Brazil"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-	card = Card{Territory: "Brazil", ArmyType: Infantry}
-	drawPile = append(drawPile, card)
-	territories["Brazil"] = &Territory{
-		Name:      "Brazil",
-		Continent: "South America",
-		Links:     []string{"Venezuela", "Peru", "Argentina", "North Africa"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-
-	// Africa
-	card = Card{Territory: "North Africa", ArmyType: Cavalry}
-	drawPile = append(drawPile, card)
-	territories["North Africa"] = &Territory{
-		Name:      "North Africa",
-		Continent: "Africa",
-		Links:     []string{"Brazil", "Congo", "East Africa", "Egypt", "Southern Europe", "Western Europe"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-	card = Card{Territory: "Egypt", ArmyType: Artillery}
-	drawPile = append(drawPile, card)
-	territories["Egypt"] = &Territory{
-		Name:      "Egypt",
-		Continent: "Africa",
-		Links:     []string{"Southern Europe", "North Africa", "East Africa", "Middle East"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-	card = Card{Territory: "Congo", ArmyType: Infantry}
-	drawPile = append(drawPile, card)
-	territories["Congo"] = &Territory{
-		Name:      "Congo",
-		Continent: "Africa",
-		Links:     []string{"North Africa", "South Africa", "East Africa"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-	card = Card{Territory: "South Africa", ArmyType: Cavalry}
-	drawPile = append(drawPile, card)
-	territories["South Africa"] = &Territory{
-		Name:      "South Africa",
-		Continent: "Africa",
-		Links:     []string{"Congo", "East Africa", "Madagascar"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-	card = Card{Territory: "Madagascar", ArmyType: Artillery}
-	drawPile = append(drawPile, card)
-	territories["Madagascar"] = &Territory{
-		Name:      "Madagascar",
-		Continent: "Africa",
-		Links:     []string{"South Africa", "East Africa"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-	card = Card{Territory: "East Africa", ArmyType: Infantry}
-	drawPile = append(drawPile, card)
-	territories["East Africa"] = &Territory{
-		Name:      "East Africa",
-		Continent: "Africa",
-		Links:     []string{"Egypt", "North Africa", "Congo", "South Africa", "Madagascar", "Middle East"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-
-	// Europe
-	card = Card{Territory: "Iceland", ArmyType: Cavalry}
-	drawPile = append(drawPile, card)
-	territories["Iceland"] = &Territory{
-		Name:      "Iceland",
-		Continent: "Europe",
-		Links:     []string{"Greenland", "Great Britain", "Scandinavia"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-	card = Card{Territory: "Great Britain", ArmyType: Artillery}
-	drawPile = append(drawPile, card)
-	territories["Great Britain"] = &Territory{
-		Name:      "Great Britain",
-		Continent: "Europe",
-		Links:     []string{"Iceland", "Western Europe", "Scandinavia", "Northern Europe"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-	card = Card{Territory: "Western Europe", ArmyType: Infantry}
-	drawPile = append(drawPile, card)
-	territories["Western Europe"] = &Territory{
-		Name:      "Western Europe",
-		Continent: "Europe",
-		Links:     []string{"Great Britain", "North Africa", "Southern Europe", "Northern Europe"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-	card = Card{Territory: "Southern Europe", ArmyType: Cavalry}
-	drawPile = append(drawPile, card)
-	territories["Southern Europe"] = &Territory{
-		Name:      "Southern Europe",
-		Continent: "Europe",
-		Links:     []string{"Western Europe", "North Africa", "Egypt", "Middle East", "Ukraine", "Northern Europe"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-	card = Card{Territory: "Northern Europe", ArmyType: Artillery}
-	drawPile = append(drawPile, card)
-	territories["Northern Europe"] = &Territory{
-		Name:      "Northern Europe",
-		Continent: "Europe",
-		Links:     []string{"Southern Europe", "Western Europe", "Great Britain", "Scandinavia", "Ukraine"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-	card = Card{Territory: "Scandinavia", ArmyType: Infantry}
-	drawPile = append(drawPile, card)
-	territories["Scandinavia"] = &Territory{
-		Name:      "Scandinavia",
-		Continent: "Europe",
-		Links:     []string{"Iceland", "Great Britain", "Northern Europe", "Ukraine"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-	card = Card{Territory: "Ukraine", ArmyType: Cavalry}
-	drawPile = append(drawPile, card)
-	territories["Ukraine"] = &Territory{
-		Name:      "Ukraine",
-		Continent: "Europe",
-		Links:     []string{"Scandinavia", "Northern Europe", "Southern Europe", "Middle East", "Afghanistan", "Ural"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-
-	// Asia
-	card = Card{Territory: "Ural", ArmyType: Artillery}
-	drawPile = append(drawPile, card)
-	territories["Ural"] = &Territory{
-		Name:      "Ural",
-		Continent: "Asia",
-		Links:     []string{"Ukraine", "Afghanistan", "China", "Siberia"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-	card = Card{Territory: "Afghanistan", ArmyType: Infantry}
-	drawPile = append(drawPile, card)
-	territories["Afghanistan"] = &Territory{
-		Name:      "Afghanistan",
-		Continent: "Asia",
-		Links:     []string{"Ukraine", "Middle East", "India", "China", "Ural"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-	card = Card{Territory: "Middle East", ArmyType: Cavalry}
-	drawPile = append(drawPile, card)
-	territories["Middle East"] = &Territory{
-		Name:      "Middle East",
-		Continent: "Asia",
-		Links:     []string{"Ukraine", "Southern Europe", "Egypt", "East Africa", "India", "Afghanistan"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-	card = Card{Territory: "Siberia", ArmyType: Artillery}
-	drawPile = append(drawPile, card)
-	territories["Siberia"] = &Territory{
-		Name:      "Siberia",
-		Continent: "Asia",
-		Links:     []string{"Ural", "China", "Mongolia", "Irkutsk", "Yakutsk"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-	card = Card{Territory: "China", ArmyType: Infantry}
-	drawPile = append(drawPile, card)
-	territories["China"] = &Territory{
-		Name:      "China",
-		Continent: "Asia",
-		Links:     []string{"Siberia", "Ural", "Afghanistan", "India", "Siam", "Mongolia"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-	card = Card{Territory: "India", ArmyType: Cavalry}
-	drawPile = append(drawPile, card)
-	territories["India"] = &Territory{
-		Name:      "India",
-		Continent: "Asia",
-		Links:     []string{"China", "Afghanistan", "Middle East", "Siam"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-	card = Card{Territory: "Yakutsk", ArmyType: Artillery}
-	drawPile = append(drawPile, card)
-	territories["Yakutsk"] = &Territory{
-		Name:      "Yakutsk",
-		Continent: "Asia",
-		Links:     []string{"Kamchatka", "Irkutsk", "Siberia"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-	card = Card{Territory: "Irkutsk", ArmyType: Infantry}
-	drawPile = append(drawPile, card)
-	territories["Irkutsk"] = &Territory{
-		Name:      "Irkutsk",
-		Continent: "Asia",
-		Links:     []string{"Yakutsk", "Siberia", "Mongolia", "Japan", "Kamchatka"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-	card = Card{Territory: "Mongolia", ArmyType: Cavalry}
-	drawPile = append(drawPile, card)
-	territories["Mongolia"] = &Territory{
-		Name:      "Mongolia",
-		Continent: "Asia",
-		Links:     []string{"Irkutsk", "Siberia", "China", "Japan"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-	card = Card{Territory: "Kamchatka", ArmyType: Artillery}
-	drawPile = append(drawPile, card)
-	territories["Kamchatka"] = &Territory{
-		Name:      "Kamchatka",
-		Continent: "Asia",
-		Links:     []string{"Yakutsk", "Irkutsk", "Japan", "Alaska"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-	card = Card{Territory: "Japan", ArmyType: Infantry}
-	drawPile = append(drawPile, card)
-	territories["Japan"] = &Territory{
-		Name:      "Japan",
-		Continent: "Asia",
-		Links:     []string{"Kamchatka", "Irkutsk", "Mongolia"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-	card = Card{Territory: "Siam", ArmyType: Cavalry}
-	drawPile = append(drawPile, card)
-	territories["Siam"] = &Territory{
-		Name:      "Siam",
-		Continent: "Asia",
-		Links:     []string{"China", "India", "Indonesia"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-
-	// Australia
-	card = Card{Territory: "Indonesia", ArmyType: Artillery}
-	drawPile = append(drawPile, card)
-	territories["Indonesia"] = &Territory{
-		Name:      "Indonesia",
-		Continent: "Australia",
-		Links:     []string{"Siam", "New Guinea", "Western Australia"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-	card = Card{Territory: "New Guinea", ArmyType: Infantry}
-	drawPile = append(drawPile, card)
-	territories["New Guinea"] = &Territory{
-		Name:      "New Guinea",
-		Continent: "Australia",
-		Links:     []string{"Indonesia", "Western Australia", "Eastern Australia"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-	card = Card{Territory: "Western Australia", ArmyType: Cavalry}
-	drawPile = append(drawPile, card)
-	territories["Western Australia"] = &Territory{
-		Name:      "Western Australia",
-		Continent: "Australia",
-		Links:     []string{"Indonesia", "New Guinea", "Eastern Australia"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
-	}
-	card = Card{Territory: "Eastern Australia", ArmyType: Artillery}
-	drawPile = append(drawPile, card)
-	territories["Eastern Australia"] = &Territory{
-		Name:      "Eastern Australia",
-		Continent: "Australia",
-		Links:     []string{"Western Australia", "New Guinea"},
-		OwnedBy:   nil,
-		Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
+	// Initialize the territories and continent bonuses from the map
+	territories := m.territories()
+	continents := make(map[string]int)
+	for _, c := range m.Continents {
+		continents[c.Name] = c.Bonus
 	}
 
 	cards := &Cards{
@@ -488,9 +210,87 @@ func NewGame(name string, players []Player) (*Game, error) {
 		Name:          name,
 		GoldenCavalry: 4,
 		Territories:   territories,
+		Continents:    continents,
 		Cards:         cards,
 		Players:       players,
+		Options:       options.withDefaults(),
+		Eliminations:  []Elimination{},
+		Winners:       []int{},
+		rng:           rand.New(rand.NewSource(seed)),
+		board:         m,
 	}
 
+	// Hand out the starting armies and begin placing them on the board
+	g.setUp()
+
 	return &g, nil
 }
+
+// UnmarshalJSON restores a game from JSON
+// Territories are pointed back at the game's own players, which they share with the rest of the game
+func (g *Game) UnmarshalJSON(data []byte) error {
+	type game Game
+	if err := json.Unmarshal(data, (*game)(g)); err != nil {
+		return err
+	}
+	for _, t := range g.Territories {
+		if t.OwnedBy != nil && t.OwnedBy.ID >= 0 && t.OwnedBy.ID < len(g.Players) {
+			t.OwnedBy = &g.Players[t.OwnedBy.ID]
+		}
+	}
+	return nil
+}
+
+// territoriesOwned returns the number of territories the player owns
+func (g *Game) territoriesOwned(playerID int) int {
+	owned := 0
+	for _, t := range g.Territories {
+		if t.isOwnedBy(playerID) {
+			owned++
+		}
+	}
+	return owned
+}
+
+// continentsOwned returns the continents in which the player owns every territory
+func (g *Game) continentsOwned(playerID int) map[string]bool {
+	owned := make(map[string]bool)
+	for _, t := range g.Territories {
+		if _, ok := owned[t.Continent]; !ok {
+			owned[t.Continent] = true
+		}
+		if !t.isOwnedBy(playerID) {
+			owned[t.Continent] = false
+		}
+	}
+	for continent, ok := range owned {
+		if !ok {
+			delete(owned, continent)
+		}
+	}
+	return owned
+}
+
+// random returns the game's source of randomness
+func (g *Game) random() *rand.Rand {
+	if g.rng == nil {
+		g.rng = rand.New(rand.NewSource(newSeed()))
+	}
+	return g.rng
+}
+
+// Map returns the map the game is played on
+func (g *Game) Map() *Map {
+	return g.board
+}
+
+// newSeed returns a seed for a source of randomness that can't be guessed
+// The seed drives the secret missions and the order of the cards, so a seed taken from the clock
+// could be worked out from the time the game was created
+func newSeed() int64 {
+	var b [8]byte
+	if _, err := crand.Read(b[:]); err != nil {
+		panic(fmt.Sprintf("Reading a random seed: %s", err))
+	}
+	return int64(binary.LittleEndian.Uint64(b[:]))
+}
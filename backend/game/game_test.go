@@ -34,7 +34,7 @@ func TestArmy(t *testing.T) {
 
 func TestAddingPlayers(t *testing.T) {
 	onePlayer := []Player{Player{ID: 0, Name: "Zero"}}
-	if _, err := NewGame("One Player", onePlayer); err == nil {
+	if _, err := NewGame("One Player", onePlayer, Options{}); err == nil {
 		t.Error("Expected an error when creating a game with one player")
 	}
 
@@ -47,7 +47,7 @@ func TestAddingPlayers(t *testing.T) {
 		Player{ID: 5, Name: "Five"},
 		Player{ID: 6, Name: "Six"},
 	}
-	if _, err := NewGame("Seven Players", sevenPlayers); err == nil {
+	if _, err := NewGame("Seven Players", sevenPlayers, Options{}); err == nil {
 		t.Error("Expected an error when creating a game with seven players")
 	}
 
@@ -56,7 +56,7 @@ func TestAddingPlayers(t *testing.T) {
 		Player{ID: 1, Name: "One"},
 		Player{ID: 2, Name: "Two"},
 	}
-	if _, err := NewGame("Bad ID", badIDPlayers); err == nil {
+	if _, err := NewGame("Bad ID", badIDPlayers, Options{}); err == nil {
 		t.Error("Expected an error when creating a game with players with gobbled indices and IDs")
 	}
 
@@ -65,7 +65,7 @@ func TestAddingPlayers(t *testing.T) {
 		Player{ID: 1, Name: "One"},
 		Player{ID: 2, Name: "Two"},
 	}
-	if _, err := NewGame("Passing Players", passingPlayers); err != nil {
+	if _, err := NewGame("Passing Players", passingPlayers, Options{}); err != nil {
 		t.Error("Expected no errors when adding three players with correct ids, got: ", err)
 	}
 }
@@ -76,7 +76,7 @@ func TestCards(t *testing.T) {
 		Player{ID: 1, Name: "One"},
 		Player{ID: 2, Name: "Two"},
 	}
-	game, err := NewGame("Test Game", players)
+	game, err := NewGame("Test Game", players, Options{})
 	if err != nil {
 		t.Error("Unexpected error while building new game:", err)
 	}
@@ -128,7 +128,7 @@ func TestTerritories(t *testing.T) {
 		Player{ID: 1, Name: "One"},
 		Player{ID: 2, Name: "Two"},
 	}
-	game, err := NewGame("Test Game", players)
+	game, err := NewGame("Test Game", players, Options{Setup: DraftSetup})
 	if err != nil {
 		t.Error("Unexpected error while building new game:", err)
 	}
@@ -153,7 +153,7 @@ func TestTerritories(t *testing.T) {
 		t.Errorf("Territory %q does not link to %q", "North Africa", "Western Europe")
 	}
 
-	// We check to make sure that no territory is owned by anyone and has no armies
+	// We check to make sure that no territory is owned by anyone and has no armies before the draft
 	// We also check that each territory links to at least one other territory
 	for _, territory := range game.Territories {
 		if territory.OwnedBy != nil {
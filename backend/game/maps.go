@@ -0,0 +1,129 @@
+package game
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// ClassicMap is the name of the classic world map every game is played on by default
+const ClassicMap = "classic"
+
+//go:embed maps/*.json
+var embeddedMaps embed.FS
+
+// Type Map is a board the game can be played on, loaded from a JSON map definition
+type Map struct {
+	Name        string         `json:"name"`
+	Continents  []Continent    `json:"continents"`
+	Territories []MapTerritory `json:"territories"`
+	// Cards is the deck of cards, including any wild cards
+	Cards []Card `json:"cards"`
+}
+
+type Continent struct {
+	Name string `json:"name"`
+	// Bonus is the number of extra armies a player receives each turn for owning every territory in the continent
+	Bonus int `json:"bonus"`
+}
+
+type MapTerritory struct {
+	Name      string   `json:"name"`
+	Continent string   `json:"continent"`
+	Links     []string `json:"links"`
+	// X and Y position the territory when drawing the board, and are left at zero when the map doesn't say
+	X float64 `json:"x,omitempty"`
+	Y float64 `json:"y,omitempty"`
+}
+
+// Type Import is a map converted from another format
+// Unsupported describes every feature of the original map that the game can't play, and so was left out
+type Import struct {
+	Map         *Map     `json:"map"`
+	Unsupported []string `json:"unsupported"`
+}
+
+// deck builds a deck of cards for the territories, cycling through the army types, with two wild cards
+func deck(territories []MapTerritory) []Card {
+	cards := []Card{
+		Card{Territory: WildTerritory, ArmyType: Wild},
+		Card{Territory: WildTerritory, ArmyType: Wild},
+	}
+	armies := []Army{Infantry, Cavalry, Artillery}
+	for i, t := range territories {
+		cards = append(cards, Card{Territory: t.Name, ArmyType: armies[i%len(armies)]})
+	}
+	return cards
+}
+
+// maps holds the maps games can be played on, keyed by name
+// Maps can be registered while games are being created, so mapsMu guards it
+var (
+	maps   = make(map[string]*Map)
+	mapsMu sync.RWMutex
+)
+
+func init() {
+	f, err := embeddedMaps.Open("maps/classic.json")
+	if err != nil {
+		panic(fmt.Sprintf("Opening the classic map: %s", err))
+	}
+	defer f.Close()
+	m, err := LoadMap(f)
+	if err != nil {
+		panic(fmt.Sprintf("Loading the classic map: %s", err))
+	}
+	RegisterMap(m)
+}
+
+// LoadMap reads a JSON map definition
+func LoadMap(r io.Reader) (*Map, error) {
+	var m Map
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// LoadMapFile reads a JSON map definition from a file
+func LoadMapFile(path string) (*Map, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return LoadMap(f)
+}
+
+// RegisterMap makes the map available to new games under its name, replacing any map of the same name
+func RegisterMap(m *Map) {
+	mapsMu.Lock()
+	defer mapsMu.Unlock()
+	maps[m.Name] = m
+}
+
+// LookupMap returns the registered map with the given name
+func LookupMap(name string) (*Map, bool) {
+	mapsMu.RLock()
+	defer mapsMu.RUnlock()
+	m, ok := maps[name]
+	return m, ok
+}
+
+// territories builds a fresh, unowned set of the map's territories keyed by name
+func (m *Map) territories() map[string]*Territory {
+	territories := make(map[string]*Territory)
+	for _, t := range m.Territories {
+		territories[t.Name] = &Territory{
+			Name:      t.Name,
+			Continent: t.Continent,
+			Links:     append([]string{}, t.Links...),
+			OwnedBy:   nil,
+			Armies:    map[Army]int{Infantry: 0, Cavalry: 0, Artillery: 0},
+		}
+	}
+	return territories
+}
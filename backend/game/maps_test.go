@@ -0,0 +1,89 @@
+package game
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const testMap = `{
+	"name": "triangle",
+	"continents": [
+		{"name": "North", "bonus": 2},
+		{"name": "South", "bonus": 1}
+	],
+	"territories": [
+		{"name": "A", "continent": "North", "links": ["B", "C"]},
+		{"name": "B", "continent": "North", "links": ["A", "C"]},
+		{"name": "C", "continent": "North", "links": ["A", "B", "D"]},
+		{"name": "D", "continent": "South", "links": ["C", "E", "F"]},
+		{"name": "E", "continent": "South", "links": ["D", "F"]},
+		{"name": "F", "continent": "South", "links": ["D", "E"]}
+	],
+	"cards": [
+		{"territory": "A", "armyType": 1},
+		{"territory": "B", "armyType": 5},
+		{"territory": "C", "armyType": 10},
+		{"territory": "D", "armyType": 1},
+		{"territory": "E", "armyType": 5},
+		{"territory": "F", "armyType": 10},
+		{"territory": "Myjäss", "armyType": 0}
+	]
+}`
+
+func TestClassicMap(t *testing.T) {
+	m, ok := LookupMap(ClassicMap)
+	if !ok {
+		t.Fatal("Expected the classic map to be registered")
+	}
+	if len(m.Territories) != 42 || len(m.Continents) != 6 || len(m.Cards) != 44 {
+		t.Errorf("Expected 42 territories, 6 continents and 44 cards, got: %d, %d and %d", len(m.Territories), len(m.Continents), len(m.Cards))
+	}
+
+	game := newTestGame(t, Options{})
+	if game.Options.Map != ClassicMap {
+		t.Errorf("Expected games to be played on the classic map by default, got: %q", game.Options.Map)
+	}
+	if game.Continents["Asia"] != 7 || game.Continents["Australia"] != 2 {
+		t.Errorf("Expected the classic continent bonuses, got: %v", game.Continents)
+	}
+}
+
+func TestCustomMap(t *testing.T) {
+	m, err := LoadMap(strings.NewReader(testMap))
+	if err != nil {
+		t.Fatal("Unexpected error loading the map:", err)
+	}
+	RegisterMap(m)
+
+	game := newTestGame(t, Options{Map: "triangle"})
+	if len(game.Territories) != 6 || len(game.Cards.DrawPile) != 7 {
+		t.Fatalf("Expected 6 territories and 7 cards, got: %d and %d", len(game.Territories), len(game.Cards.DrawPile))
+	}
+	if links := game.Territories["C"].Links; len(links) != 3 {
+		t.Errorf("Expected C to link to 3 territories, got: %v", links)
+	}
+
+	giveTerritories(game, "A", "B", "C")
+	r, err := game.Reinforcements(0)
+	if err != nil {
+		t.Fatal("Unexpected error calculating reinforcements:", err)
+	}
+	if r.Continents["North"] != 2 || r.Total != 5 {
+		t.Errorf("Expected the North bonus of 2 on top of 3 base armies, got: %v", r)
+	}
+
+	var invalidOption *InvalidOptionError
+	if _, err := NewGame("Missions", game.Players, Options{Map: "triangle", Mode: SecretMission}); !errors.As(err, &invalidOption) {
+		t.Errorf("Expected an InvalidOptionError playing secret missions on a custom map, got: %v", err)
+	}
+	if _, err := NewGame("Atlantis", game.Players, Options{Map: "atlantis"}); !errors.As(err, &invalidOption) {
+		t.Errorf("Expected an InvalidOptionError playing on an unknown map, got: %v", err)
+	}
+}
+
+func TestLoadMapError(t *testing.T) {
+	if _, err := LoadMap(strings.NewReader(`{"name": `)); err == nil {
+		t.Error("Expected an error loading a truncated map")
+	}
+}
@@ -0,0 +1,156 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+type MissionType string
+
+const (
+	ConquerContinentsMission MissionType = "conquerContinents"
+	OccupyTerritoriesMission MissionType = "occupyTerritories"
+	DestroyPlayerMission     MissionType = "destroyPlayer"
+)
+
+// Type Mission is a player's secret objective in a secret mission game
+type Mission struct {
+	Type        MissionType `json:"type"`
+	Description string      `json:"description"`
+	// Continents must all be conquered, along with ExtraContinents more continents of the player's choosing
+	Continents      []string `json:"continents,omitempty"`
+	ExtraContinents int      `json:"extraContinents,omitempty"`
+	// Territories is the number of territories that must be occupied with at least MinArmies armies each
+	Territories int `json:"territories,omitempty"`
+	MinArmies   int `json:"minArmies,omitempty"`
+	// TargetID is the player who must be destroyed
+	TargetID int `json:"targetId,omitempty"`
+}
+
+// fallbackMission replaces a mission to destroy a player who is yourself, or who was eliminated by someone else
+var fallbackMission = Mission{
+	Type:        OccupyTerritoriesMission,
+	Description: "Occupy 24 territories",
+	Territories: 24,
+	MinArmies:   1,
+}
+
+// missionDeck builds the classic deck of secret missions, with one mission to destroy each player in the game
+func (g *Game) missionDeck() []*Mission {
+	deck := []*Mission{}
+	for _, continents := range [][]string{
+		[]string{"North America", "Africa"},
+		[]string{"North America", "Australia"},
+		[]string{"Asia", "South America"},
+		[]string{"Asia", "Africa"},
+	} {
+		deck = append(deck, &Mission{
+			Type:        ConquerContinentsMission,
+			Description: fmt.Sprintf("Conquer %s", strings.Join(continents, " and ")),
+			Continents:  continents,
+		})
+	}
+	for _, continents := range [][]string{
+		[]string{"Europe", "South America"},
+		[]string{"Europe", "Australia"},
+	} {
+		deck = append(deck, &Mission{
+			Type:            ConquerContinentsMission,
+			Description:     fmt.Sprintf("Conquer %s and one other continent of your choice", strings.Join(continents, " and ")),
+			Continents:      continents,
+			ExtraContinents: 1,
+		})
+	}
+
+	deck = append(deck, &Mission{
+		Type:        OccupyTerritoriesMission,
+		Description: "Occupy 18 territories with at least 2 armies in each",
+		Territories: 18,
+		MinArmies:   2,
+	})
+	fallback := fallbackMission
+	deck = append(deck, &fallback)
+
+	for _, p := range g.Players {
+		deck = append(deck, &Mission{
+			Type:        DestroyPlayerMission,
+			Description: fmt.Sprintf("Destroy all of %s's armies", p.Name),
+			TargetID:    p.ID,
+		})
+	}
+	return deck
+}
+
+// dealMissions deals each player a secret mission
+// A player dealt the mission to destroy themselves or a teammate must occupy 24 territories instead
+func (g *Game) dealMissions() {
+	deck := g.missionDeck()
+	g.random().Shuffle(len(deck), func(i, j int) {
+		deck[i], deck[j] = deck[j], deck[i]
+	})
+
+	g.Missions = make(map[int]*Mission)
+	for i, p := range g.Players {
+		mission := deck[i]
+		if mission.Type == DestroyPlayerMission && (mission.TargetID == p.ID || g.isTeammate(p.ID, mission.TargetID)) {
+			fallback := fallbackMission
+			mission = &fallback
+		}
+		g.Missions[p.ID] = mission
+	}
+}
+
+// missionWinners returns the current player once they have completed their mission
+// Missions can only be completed during a player's own turn
+func (g *Game) missionWinners() []int {
+	switch g.Phase {
+	case ReinforcePhase, AttackPhase, FortifyPhase:
+	default:
+		return nil
+	}
+	if g.PendingConquest != nil {
+		return nil
+	}
+	mission, ok := g.Missions[g.CurrentPlayer]
+	if !ok {
+		return nil
+	}
+	if g.missionComplete(g.CurrentPlayer, mission) {
+		return []int{g.CurrentPlayer}
+	}
+	return nil
+}
+
+func (g *Game) missionComplete(playerID int, mission *Mission) bool {
+	switch mission.Type {
+	case ConquerContinentsMission:
+		owned := g.continentsOwned(playerID)
+		for _, continent := range mission.Continents {
+			if !owned[continent] {
+				return false
+			}
+			delete(owned, continent)
+		}
+		return len(owned) >= mission.ExtraContinents
+	case OccupyTerritoriesMission:
+		occupied := 0
+		for _, t := range g.Territories {
+			if t.isOwnedBy(playerID) && t.Strength() >= mission.MinArmies {
+				occupied++
+			}
+		}
+		return occupied >= mission.Territories
+	case DestroyPlayerMission:
+		elimination := g.elimination(mission.TargetID)
+		if elimination == nil {
+			return false
+		}
+		if elimination.EliminatedBy == playerID {
+			return true
+		}
+		// Someone else got to the target first
+		fallback := fallbackMission
+		return g.missionComplete(playerID, &fallback)
+	}
+	return false
+}
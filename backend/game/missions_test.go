@@ -0,0 +1,158 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestDealMissions(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		game := newTestGame(t, Options{Mode: SecretMission})
+		if len(game.Missions) != len(game.Players) {
+			t.Fatalf("Expected every player to be dealt a mission, got: %v", game.Missions)
+		}
+		for playerID, mission := range game.Missions {
+			if mission.Type == DestroyPlayerMission && mission.TargetID == playerID {
+				t.Errorf("Player %d was dealt the mission to destroy themselves", playerID)
+			}
+			if mission.Description == "" {
+				t.Errorf("Player %d's mission has no description", playerID)
+			}
+		}
+	}
+
+	game := newTestGame(t, Options{})
+	if len(game.Missions) != 0 {
+		t.Errorf("Expected no missions in a world domination game, got: %v", game.Missions)
+	}
+}
+
+func TestMissionComplete(t *testing.T) {
+	northAmerica := []string{"Alaska", "Alberta", "Western United States", "Central America", "Northwest Territory", "Ontario", "Eastern United States", "Greenland", "Quebec"}
+	australia := []string{"Indonesia", "New Guinea", "Western Australia", "Eastern Australia"}
+	southAmerica := []string{"Venezuela", "Peru", "Argentina", "Brazil"}
+	europe := []string{"Iceland", "Great Britain", "Western Europe", "Southern Europe", "Northern Europe", "Scandinavia", "Ukraine"}
+
+	conquerNorthAmericaAustralia := &Mission{Type: ConquerContinentsMission, Continents: []string{"North America", "Australia"}}
+	conquerEuropeAustraliaPlusOne := &Mission{Type: ConquerContinentsMission, Continents: []string{"Europe", "Australia"}, ExtraContinents: 1}
+	destroyTwo := &Mission{Type: DestroyPlayerMission, TargetID: 2}
+
+	testCases := []struct {
+		name         string
+		mission      *Mission
+		territories  [][]string
+		armies       int
+		eliminatedBy int
+		complete     bool
+	}{
+		{
+			name:        "ContinentsMissing",
+			mission:     conquerNorthAmericaAustralia,
+			territories: [][]string{northAmerica, australia[1:]},
+			complete:    false,
+		},
+		{
+			name:        "Continents",
+			mission:     conquerNorthAmericaAustralia,
+			territories: [][]string{northAmerica, australia},
+			complete:    true,
+		},
+		{
+			name:        "ExtraContinentMissing",
+			mission:     conquerEuropeAustraliaPlusOne,
+			territories: [][]string{europe, australia},
+			complete:    false,
+		},
+		{
+			name:        "ExtraContinent",
+			mission:     conquerEuropeAustraliaPlusOne,
+			territories: [][]string{europe, australia, southAmerica},
+			complete:    true,
+		},
+		{
+			name:        "OccupyTooFewArmies",
+			mission:     &Mission{Type: OccupyTerritoriesMission, Territories: 18, MinArmies: 2},
+			territories: [][]string{northAmerica, europe, southAmerica},
+			armies:      1,
+			complete:    false,
+		},
+		{
+			name:        "Occupy",
+			mission:     &Mission{Type: OccupyTerritoriesMission, Territories: 18, MinArmies: 2},
+			territories: [][]string{northAmerica, europe, southAmerica},
+			armies:      2,
+			complete:    true,
+		},
+		{
+			name:         "DestroyNotYet",
+			mission:      destroyTwo,
+			territories:  [][]string{northAmerica},
+			eliminatedBy: -1,
+			complete:     false,
+		},
+		{
+			name:         "Destroy",
+			mission:      destroyTwo,
+			territories:  [][]string{northAmerica},
+			eliminatedBy: 0,
+			complete:     true,
+		},
+		{
+			name:         "DestroyedBySomeoneElse",
+			mission:      destroyTwo,
+			territories:  [][]string{northAmerica},
+			eliminatedBy: 1,
+			complete:     false,
+		},
+		{
+			name:         "DestroyedBySomeoneElseFallback",
+			mission:      destroyTwo,
+			territories:  [][]string{northAmerica, europe, southAmerica, australia},
+			armies:       1,
+			eliminatedBy: 1,
+			complete:     true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			game := newTestGame(t, Options{Mode: SecretMission})
+			names := []string{}
+			for _, territories := range testCase.territories {
+				names = append(names, territories...)
+			}
+			giveTerritories(game, names...)
+			for _, name := range names {
+				game.Territories[name].Armies = map[Army]int{Infantry: testCase.armies, Cavalry: 0, Artillery: 0}
+			}
+			if testCase.eliminatedBy >= 0 && testCase.mission.Type == DestroyPlayerMission {
+				game.Eliminations = []Elimination{Elimination{PlayerID: 2, EliminatedBy: testCase.eliminatedBy, Turn: 1, Order: 1}}
+			}
+
+			if game.missionComplete(0, testCase.mission) != testCase.complete {
+				t.Errorf("Expected mission completion to be %t", testCase.complete)
+			}
+		})
+	}
+}
+
+func TestMissionVictory(t *testing.T) {
+	game := newTestGame(t, Options{Mode: SecretMission})
+	giveTerritories(game, "Alaska", "Alberta", "Western United States", "Central America", "Northwest Territory", "Ontario", "Eastern United States", "Greenland", "Quebec",
+		"Indonesia", "New Guinea", "Western Australia", "Eastern Australia")
+	game.Missions[0] = &Mission{Type: ConquerContinentsMission, Continents: []string{"North America", "Australia"}}
+	game.Reserves = map[int]int{0: 0, 1: 0, 2: 0}
+
+	// Missions aren't checked until the player's turn
+	game.checkVictory()
+	if game.Phase == GameOverPhase {
+		t.Fatal("Expected missions not to be checked during placement")
+	}
+
+	game.beginTurns()
+	if err := game.PlaceReinforcements(0, map[string]int{"Alaska": 1}); err != nil {
+		t.Fatal("Unexpected error placing reinforcements:", err)
+	}
+	if game.Phase != GameOverPhase || len(game.Winners) != 1 || game.Winners[0] != 0 {
+		t.Errorf("Expected player 0 to win by completing their mission, got: %q with winners %v", game.Phase, game.Winners)
+	}
+}
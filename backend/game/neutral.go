@@ -0,0 +1,88 @@
+package game
+
+import (
+	"sort"
+)
+
+// neutral returns the neutral player of a two-player game, or nil if there isn't one
+func (g *Game) neutral() *Player {
+	for i := range g.Players {
+		if g.Players[i].Neutral {
+			return &g.Players[i]
+		}
+	}
+	return nil
+}
+
+func (g *Game) isNeutral(playerID int) bool {
+	return playerID >= 0 && playerID < len(g.Players) && g.Players[playerID].Neutral
+}
+
+// opponent returns the other human player of a two-player game
+func (g *Game) opponent(playerID int) int {
+	for _, p := range g.Players {
+		if p.ID != playerID && !p.Neutral {
+			return p.ID
+		}
+	}
+	return playerID
+}
+
+// SetNeutralDice sets the number of dice the player rolls for the neutral player when their opponent attacks it
+// This is a standing choice rather than one made for each attack, so an attack never has to wait on the other
+// player: the choice holds for every attack on the neutral player until it's changed again
+// The neutral player defends with as many dice as it can until this is set
+func (g *Game) SetNeutralDice(playerID int, dice int) error {
+	if playerID < 0 || playerID >= len(g.Players) || g.isNeutral(playerID) {
+		return &UnknownPlayerError{ID: playerID}
+	}
+	if g.Phase == GameOverPhase {
+		return &GameOverError{}
+	}
+	if g.neutral() == nil {
+		return &NoNeutralPlayerError{}
+	}
+	if dice < 1 || dice > 2 {
+		return &InvalidDiceError{Dice: dice, Max: 2}
+	}
+
+	g.record(&NeutralDiceSet{PlayerID: playerID, Dice: dice})
+	if g.NeutralDice == nil {
+		g.NeutralDice = make(map[int]int)
+	}
+	g.NeutralDice[playerID] = dice
+	return nil
+}
+
+// dealNeutralTerritories deals the neutral player a third of the territories at random, before the players draft the rest
+func (g *Game) dealNeutralTerritories() {
+	neutral := g.neutral()
+	names := make([]string, 0, len(g.Territories))
+	for name := range g.Territories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	g.random().Shuffle(len(names), func(i, j int) {
+		names[i], names[j] = names[j], names[i]
+	})
+
+	for _, name := range names[:len(names)/len(g.Players)] {
+		g.occupy(g.Territories[name], neutral)
+	}
+}
+
+// placeNeutralArmies places the rest of the neutral player's starting armies on its territories at random
+func (g *Game) placeNeutralArmies() {
+	neutral := g.neutral()
+	names := []string{}
+	for name, t := range g.Territories {
+		if t.isOwnedBy(neutral.ID) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for ; g.Reserves[neutral.ID] > 0; g.Reserves[neutral.ID]-- {
+		g.Territories[names[g.random().Intn(len(names))]].AddArmies(1)
+	}
+}
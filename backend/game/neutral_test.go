@@ -0,0 +1,131 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+func newTwoPlayerGame(t *testing.T, setup SetupMode) *Game {
+	players := []Player{
+		Player{ID: 0, Name: "Zero"},
+		Player{ID: 1, Name: "One"},
+	}
+	game, err := NewGame("Two Player Game", players, Options{TwoPlayer: true, Setup: setup})
+	if err != nil {
+		t.Fatal("Unexpected error while building new game:", err)
+	}
+	return game
+}
+
+func TestTwoPlayerPlayerCount(t *testing.T) {
+	threePlayers := []Player{
+		Player{ID: 0, Name: "Zero"},
+		Player{ID: 1, Name: "One"},
+		Player{ID: 2, Name: "Two"},
+	}
+	var incorrectNumber *IncorrectNumberOfPlayersError
+	if _, err := NewGame("Three Players", threePlayers, Options{TwoPlayer: true}); !errors.As(err, &incorrectNumber) {
+		t.Errorf("Expected an IncorrectNumberOfPlayersError creating a two-player game with three players, got: %v", err)
+	}
+	if _, err := NewGame("Two Players", threePlayers[:2], Options{}); !errors.As(err, &incorrectNumber) {
+		t.Errorf("Expected an IncorrectNumberOfPlayersError creating a standard game with two players, got: %v", err)
+	}
+	var invalidOption *InvalidOptionError
+	if _, err := NewGame("Two Players", threePlayers[:2], Options{TwoPlayer: true, Mode: SecretMission}); !errors.As(err, &invalidOption) {
+		t.Errorf("Expected an InvalidOptionError creating a two-player secret mission game, got: %v", err)
+	}
+}
+
+func TestTwoPlayerSetup(t *testing.T) {
+	for _, setup := range []SetupMode{RandomSetup, DraftSetup} {
+		game := newTwoPlayerGame(t, setup)
+		if len(game.Players) != 3 || !game.Players[2].Neutral {
+			t.Fatalf("%s: Expected a neutral third player to be added, got: %v", setup, game.Players)
+		}
+
+		territories, armies := 0, 0
+		for _, territory := range game.Territories {
+			if territory.isOwnedBy(2) {
+				territories++
+				armies += territory.Strength()
+			}
+		}
+		if territories != 14 || armies != 35 || game.Reserves[2] != 0 {
+			t.Errorf("%s: Expected the neutral player to have all 35 armies on 14 territories, got: %d armies on %d territories with %d to place", setup, armies, territories, game.Reserves[2])
+		}
+
+		if setup == DraftSetup {
+			for name, territory := range game.Territories {
+				if territory.OwnedBy == nil {
+					if err := game.Claim(game.CurrentPlayer, name); err != nil {
+						t.Fatalf("%s: Unexpected error claiming %q: %s", setup, name, err)
+					}
+				}
+			}
+		}
+		placeAllArmies(t, game)
+
+		// The neutral player never takes a turn
+		for _, want := range []int{0, 1, 0} {
+			if game.CurrentPlayer != want {
+				t.Errorf("%s: Expected it to be player %d's turn, got: %d", setup, want, game.CurrentPlayer)
+			}
+			placeReinforcements(t, game)
+			if err := game.EndTurn(game.CurrentPlayer); err != nil {
+				t.Fatalf("%s: Unexpected error ending the turn: %s", setup, err)
+			}
+		}
+	}
+}
+
+func TestNeutralDice(t *testing.T) {
+	game := newTwoPlayerGame(t, RandomSetup)
+	for name, territory := range game.Territories {
+		territory.OwnedBy = &game.Players[2]
+		territory.Armies = map[Army]int{Infantry: 3, Cavalry: 0, Artillery: 0}
+		if name == "Alaska" {
+			territory.OwnedBy = &game.Players[0]
+		}
+		if name == "Kamchatka" {
+			territory.OwnedBy = &game.Players[1]
+		}
+	}
+	game.beginTurns()
+	game.Reserves = map[int]int{0: 0, 1: 0, 2: 0}
+	game.Phase = AttackPhase
+	game.SetDice(&fixedDice{rolls: []int{6}})
+
+	result, err := game.Attack(0, "Alaska", "Alberta", 1)
+	if err != nil {
+		t.Fatal("Unexpected error attacking the neutral player:", err)
+	}
+	if len(result.DefenderRolls) != 2 {
+		t.Errorf("Expected the neutral player to defend with two dice by default, got: %v", result.DefenderRolls)
+	}
+
+	var invalidDice *InvalidDiceError
+	if err := game.SetNeutralDice(1, 3); !errors.As(err, &invalidDice) {
+		t.Errorf("Expected an InvalidDiceError rolling three dice for the neutral player, got: %v", err)
+	}
+	var unknownPlayer *UnknownPlayerError
+	if err := game.SetNeutralDice(2, 1); !errors.As(err, &unknownPlayer) {
+		t.Errorf("Expected an UnknownPlayerError when the neutral player chooses its own dice, got: %v", err)
+	}
+
+	// Player 1 chooses the neutral player's dice while player 0 attacks
+	if err := game.SetNeutralDice(1, 1); err != nil {
+		t.Fatal("Unexpected error setting the neutral player's dice:", err)
+	}
+	result, err = game.Attack(0, "Alaska", "Alberta", 1)
+	if err != nil {
+		t.Fatal("Unexpected error attacking the neutral player:", err)
+	}
+	if len(result.DefenderRolls) != 1 {
+		t.Errorf("Expected the neutral player to defend with the one die player 1 chose, got: %v", result.DefenderRolls)
+	}
+
+	var noNeutral *NoNeutralPlayerError
+	if err := newTestGame(t, Options{}).SetNeutralDice(1, 1); !errors.As(err, &noNeutral) {
+		t.Errorf("Expected a NoNeutralPlayerError outside of a two-player game, got: %v", err)
+	}
+}
@@ -0,0 +1,106 @@
+package game
+
+type SetupMode string
+
+const (
+	// RandomSetup deals the territories out to the players at random
+	RandomSetup SetupMode = "random"
+	// DraftSetup has the players claim the territories one at a time, in turn
+	DraftSetup SetupMode = "draft"
+)
+
+type FortifyRule string
+
+const (
+	// AdjacentFortify allows a single fortification between two adjacent territories
+	AdjacentFortify FortifyRule = "adjacent"
+	// ConnectedFortify allows a single fortification between two territories connected by the player's own territories
+	ConnectedFortify FortifyRule = "connected"
+	// UnlimitedFortify allows any number of fortifications between connected territories
+	UnlimitedFortify FortifyRule = "unlimited"
+)
+
+type Mode string
+
+const (
+	// WorldDomination is won by eliminating every other player
+	WorldDomination Mode = "worldDomination"
+	// SecretMission deals each player a secret mission, which wins the game once completed
+	SecretMission Mode = "secretMission"
+	// CapitalRisk has each player designate a capital, and is won by holding every capital at once
+	CapitalRisk Mode = "capitalRisk"
+)
+
+// Type Options holds the house rules a game is played with
+// The zero value of each option selects the standard rules
+type Options struct {
+	Mode    Mode        `json:"mode"`
+	Setup   SetupMode   `json:"setup"`
+	Fortify FortifyRule `json:"fortify"`
+	// TwoPlayer plays a two-player game against a neutral player, which is added to the players
+	TwoPlayer bool `json:"twoPlayer"`
+	// Teams plays the game in teams, using the TeamID of each player
+	Teams bool `json:"teams"`
+	// CardTransfers allows teammates to give each other cards during the reinforce phase of a team game
+	CardTransfers bool `json:"cardTransfers"`
+	// Map is the name of the map to play on
+	Map string `json:"map"`
+}
+
+// withDefaults returns a copy of the options with any unset option replaced by its default
+func (o Options) withDefaults() Options {
+	if o.Mode == "" {
+		o.Mode = WorldDomination
+	}
+	if o.Setup == "" {
+		o.Setup = RandomSetup
+	}
+	if o.Fortify == "" {
+		o.Fortify = ConnectedFortify
+	}
+	if o.Map == "" {
+		o.Map = ClassicMap
+	}
+	return o
+}
+
+func (o Options) validate() error {
+	switch o.Mode {
+	case "", WorldDomination, SecretMission, CapitalRisk:
+	default:
+		return &InvalidOptionError{Option: "mode", Value: string(o.Mode)}
+	}
+	switch o.Setup {
+	case "", RandomSetup, DraftSetup:
+	default:
+		return &InvalidOptionError{Option: "setup", Value: string(o.Setup)}
+	}
+	switch o.Fortify {
+	case "", AdjacentFortify, ConnectedFortify, UnlimitedFortify:
+	default:
+		return &InvalidOptionError{Option: "fortify", Value: string(o.Fortify)}
+	}
+	// The neutral player can't complete a mission or designate a capital, so two-player games are played for world domination
+	if o.TwoPlayer && o.Mode != "" && o.Mode != WorldDomination {
+		return &InvalidOptionError{Option: "mode", Value: string(o.Mode)}
+	}
+	if o.TwoPlayer && o.Teams {
+		return &InvalidOptionError{Option: "teams", Value: "true"}
+	}
+	if o.CardTransfers && !o.Teams {
+		return &InvalidOptionError{Option: "cardTransfers", Value: "true"}
+	}
+	// The secret missions are written for the continents of the classic map
+	if o.Mode == SecretMission && o.Map != "" && o.Map != ClassicMap {
+		return &InvalidOptionError{Option: "mode", Value: string(o.Mode)}
+	}
+	return nil
+}
+
+// playerLimits returns the minimum and maximum number of players the options allow, not counting any neutral player
+func (o Options) playerLimits() (int, int) {
+	if o.TwoPlayer {
+		return 2, 2
+	}
+	return 3, 6
+}
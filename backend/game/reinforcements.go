@@ -0,0 +1,129 @@
+package game
+
+// Type Reinforcement breaks down the armies a player receives at the start of their turn
+type Reinforcement struct {
+	// Base is one army for every three territories owned, with a minimum of three
+	Base int `json:"base"`
+	// Continents holds the bonus for each continent the player owns outright
+	Continents map[string]int `json:"continents"`
+	// Cards is the number of armies received from trading in cards this turn
+	Cards int `json:"cards"`
+	Total int `json:"total"`
+}
+
+// Reinforcements calculates the armies the player receives at the start of their turn
+func (g *Game) Reinforcements(playerID int) (*Reinforcement, error) {
+	if playerID < 0 || playerID >= len(g.Players) {
+		return nil, &UnknownPlayerError{ID: playerID}
+	}
+
+	r := &Reinforcement{
+		Base:       g.territoriesOwned(playerID) / 3,
+		Continents: make(map[string]int),
+	}
+	if r.Base < 3 {
+		r.Base = 3
+	}
+	r.Total = r.Base
+
+	for continent := range g.continentsOwned(playerID) {
+		r.Continents[continent] = g.Continents[continent]
+		r.Total += g.Continents[continent]
+	}
+
+	// Cards traded in count towards the reinforcements of the player whose turn it is
+	if playerID == g.CurrentPlayer && g.Reinforcement != nil {
+		r.Cards = g.Reinforcement.Cards
+		r.Total += r.Cards
+	}
+
+	return r, nil
+}
+
+// reinforce hands the current player the armies they receive at the start of their turn
+func (g *Game) reinforce() {
+	// Any trades from the previous turn must not carry over into the new one
+	g.Reinforcement = nil
+
+	r, _ := g.Reinforcements(g.CurrentPlayer)
+	g.Reinforcement = r
+	g.Reserves[g.CurrentPlayer] += r.Total
+}
+
+// PlaceReinforcements places the player's reinforcements on the territories they own
+// The placements map territory names to the number of armies to place there,
+// and may not add up to more armies than the player has left to place
+// Armies from a trade forced by eliminating another player are placed during the attack phase
+func (g *Game) PlaceReinforcements(playerID int, placements map[string]int) error {
+	if err := g.checkTurn(playerID, ReinforcePhase, AttackPhase); err != nil {
+		return err
+	}
+	if err := g.checkHandSize(playerID); err != nil {
+		return err
+	}
+
+	total := 0
+	for territory, armies := range placements {
+		t, ok := g.Territories[territory]
+		if !ok {
+			return &UnknownTerritoryError{Name: territory}
+		}
+		if !t.isOwnedBy(playerID) {
+			return &TerritoryNotOwnedError{Territory: territory, PlayerID: playerID}
+		}
+		if armies < 1 {
+			return &InvalidArmiesError{Armies: armies, Min: 1, Max: g.Reserves[playerID]}
+		}
+		total += armies
+	}
+	if total > g.Reserves[playerID] {
+		return &TooManyArmiesError{Armies: total, Available: g.Reserves[playerID]}
+	}
+
+	recorded := make(map[string]int)
+	for territory, armies := range placements {
+		recorded[territory] = armies
+	}
+	g.record(&Reinforced{PlayerID: playerID, Placements: recorded})
+	for territory, armies := range placements {
+		g.Territories[territory].AddArmies(armies)
+	}
+	g.Reserves[playerID] -= total
+	g.checkVictory()
+	return nil
+}
+
+// PlaceArmies places the player's armies in whichever way the current phase places armies
+// While the players claim territories or place their starting armies one at a time,
+// the placements must name a single territory to claim or to place a single army on,
+// and otherwise the placements are the player's reinforcements
+func (g *Game) PlaceArmies(playerID int, placements map[string]int) error {
+	if g.Phase != ClaimPhase && g.Phase != PlacementPhase {
+		return g.PlaceReinforcements(playerID, placements)
+	}
+	if err := g.checkTurn(playerID, ClaimPhase, PlacementPhase); err != nil {
+		return err
+	}
+
+	total := 0
+	territory := ""
+	for name, armies := range placements {
+		total += armies
+		territory = name
+	}
+	if len(placements) != 1 || total != 1 {
+		return &InvalidArmiesError{Armies: total, Min: 1, Max: 1}
+	}
+	if g.Phase == ClaimPhase {
+		return g.Claim(playerID, territory)
+	}
+	return g.Place(playerID, territory)
+}
+
+// checkReinforcementsPlaced returns an error if the player still has reinforcements to place
+func (g *Game) checkReinforcementsPlaced(playerID int) error {
+	if g.Reserves[playerID] > 0 {
+		return &UnplacedArmiesError{Armies: g.Reserves[playerID]}
+	}
+	return nil
+}
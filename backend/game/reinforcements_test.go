@@ -0,0 +1,190 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+// placeReinforcements has the current player place all of their reinforcements on the first territory they own
+func placeReinforcements(t *testing.T, game *Game) {
+	for name, territory := range game.Territories {
+		if territory.isOwnedBy(game.CurrentPlayer) {
+			placements := map[string]int{name: game.Reserves[game.CurrentPlayer]}
+			if err := game.PlaceReinforcements(game.CurrentPlayer, placements); err != nil {
+				t.Fatal("Unexpected error placing reinforcements:", err)
+			}
+			return
+		}
+	}
+	t.Fatalf("Player %d owns no territories to place reinforcements on", game.CurrentPlayer)
+}
+
+// giveTerritories hands the named territories to player 0 and every other territory to player 1
+func giveTerritories(game *Game, names ...string) {
+	for _, territory := range game.Territories {
+		territory.OwnedBy = &game.Players[1]
+	}
+	for _, name := range names {
+		game.Territories[name].OwnedBy = &game.Players[0]
+	}
+}
+
+func TestReinforcements(t *testing.T) {
+	australia := []string{"Indonesia", "New Guinea", "Western Australia", "Eastern Australia"}
+	southAmerica := []string{"Venezuela", "Peru", "Argentina", "Brazil"}
+	africa := []string{"North Africa", "Egypt", "Congo", "South Africa", "Madagascar", "East Africa"}
+
+	testCases := []struct {
+		name        string
+		territories []string
+		base        int
+		continents  map[string]int
+		total       int
+	}{
+		{
+			name:        "Minimum",
+			territories: []string{"Alaska", "Peru"},
+			base:        3,
+			continents:  map[string]int{},
+			total:       3,
+		},
+		{
+			name:        "Australia",
+			territories: australia,
+			base:        3,
+			continents:  map[string]int{"Australia": 2},
+			total:       5,
+		},
+		{
+			name:        "ThreeContinents",
+			territories: append(append(append([]string{}, australia...), southAmerica...), africa...),
+			base:        4,
+			continents:  map[string]int{"Australia": 2, "South America": 2, "Africa": 3},
+			total:       11,
+		},
+		{
+			name:        "AllButOne",
+			territories: nil,
+			base:        13,
+			continents:  map[string]int{"North America": 5, "South America": 2, "Europe": 5, "Africa": 3, "Australia": 2},
+			total:       30,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			game := newTestGame(t, Options{})
+			giveTerritories(game, testCase.territories...)
+			if testCase.territories == nil {
+				for _, territory := range game.Territories {
+					territory.OwnedBy = &game.Players[0]
+				}
+				game.Territories["Kamchatka"].OwnedBy = &game.Players[1]
+			}
+
+			r, err := game.Reinforcements(0)
+			if err != nil {
+				t.Fatal("Unexpected error calculating reinforcements:", err)
+			}
+			if r.Base != testCase.base {
+				t.Errorf("Expected a base of %d armies, got: %d", testCase.base, r.Base)
+			}
+			if len(r.Continents) != len(testCase.continents) {
+				t.Errorf("Expected continent bonuses %v, got: %v", testCase.continents, r.Continents)
+			}
+			for continent, bonus := range testCase.continents {
+				if r.Continents[continent] != bonus {
+					t.Errorf("Expected a bonus of %d for %s, got: %d", bonus, continent, r.Continents[continent])
+				}
+			}
+			if r.Total != testCase.total {
+				t.Errorf("Expected %d armies in total, got: %d", testCase.total, r.Total)
+			}
+		})
+	}
+}
+
+func TestPlaceReinforcements(t *testing.T) {
+	game := newTestGame(t, Options{})
+	giveTerritories(game, "Indonesia", "New Guinea", "Western Australia", "Eastern Australia")
+	game.Reserves = map[int]int{0: 0, 1: 0, 2: 0}
+	game.beginTurns()
+
+	if game.Reserves[0] != 5 {
+		t.Fatalf("Expected player 0 to start their turn with 5 armies to place, got: %d", game.Reserves[0])
+	}
+	if game.Reinforcement == nil || game.Reinforcement.Total != 5 {
+		t.Errorf("Expected the turn's reinforcement breakdown to total 5, got: %v", game.Reinforcement)
+	}
+
+	var unplaced *UnplacedArmiesError
+	if err := game.EndPhase(0); !errors.As(err, &unplaced) {
+		t.Errorf("Expected an UnplacedArmiesError when ending the phase with armies left to place, got: %v", err)
+	}
+	var tooMany *TooManyArmiesError
+	if err := game.PlaceReinforcements(0, map[string]int{"Indonesia": 4, "Siam": 0}); err == nil {
+		t.Error("Expected an error when placing on a territory owned by another player")
+	}
+	if err := game.PlaceReinforcements(0, map[string]int{"Indonesia": 4, "New Guinea": 2}); !errors.As(err, &tooMany) {
+		t.Errorf("Expected a TooManyArmiesError when placing six armies, got: %v", err)
+	}
+	var invalidArmies *InvalidArmiesError
+	if err := game.PlaceReinforcements(0, map[string]int{"Indonesia": -1}); !errors.As(err, &invalidArmies) {
+		t.Errorf("Expected an InvalidArmiesError when placing negative armies, got: %v", err)
+	}
+
+	if err := game.PlaceReinforcements(0, map[string]int{"Indonesia": 2, "New Guinea": 1}); err != nil {
+		t.Fatal("Unexpected error placing reinforcements:", err)
+	}
+	if err := game.PlaceReinforcements(0, map[string]int{"Eastern Australia": 2}); err != nil {
+		t.Fatal("Unexpected error placing reinforcements:", err)
+	}
+	if game.Territories["Indonesia"].Strength() != 3 || game.Territories["Eastern Australia"].Strength() != 3 {
+		t.Errorf("Expected reinforcements to be added to the territories, got: %d and %d", game.Territories["Indonesia"].Strength(), game.Territories["Eastern Australia"].Strength())
+	}
+	if err := game.EndPhase(0); err != nil {
+		t.Error("Unexpected error ending the phase once all armies are placed:", err)
+	}
+}
+
+func TestPlaceArmies(t *testing.T) {
+	game := newTestGame(t, Options{Setup: DraftSetup})
+
+	var invalidArmies *InvalidArmiesError
+	if err := game.PlaceArmies(0, map[string]int{"Alaska": 1, "Peru": 1}); !errors.As(err, &invalidArmies) {
+		t.Errorf("Expected an InvalidArmiesError claiming two territories, got: %v", err)
+	}
+	var notYourTurn *NotYourTurnError
+	if err := game.PlaceArmies(1, map[string]int{"Alaska": 1}); !errors.As(err, &notYourTurn) {
+		t.Errorf("Expected a NotYourTurnError claiming out of turn, got: %v", err)
+	}
+	if err := game.PlaceArmies(0, map[string]int{"Alaska": 1}); err != nil {
+		t.Fatal("Unexpected error claiming a territory:", err)
+	}
+	if !game.Territories["Alaska"].isOwnedBy(0) {
+		t.Errorf("Expected player 0 to claim Alaska")
+	}
+
+	game = newTestGame(t, Options{})
+	territory := frontLine(game, 0)
+	if err := game.PlaceArmies(0, map[string]int{territory: 2}); !errors.As(err, &invalidArmies) {
+		t.Errorf("Expected an InvalidArmiesError placing two starting armies at once, got: %v", err)
+	}
+	if err := game.PlaceArmies(0, map[string]int{territory: 1}); err != nil {
+		t.Fatal("Unexpected error placing a starting army:", err)
+	}
+	if game.Territories[territory].Strength() != 2 || game.CurrentPlayer != 1 {
+		t.Errorf("Expected a starting army on %s, got strength %d", territory, game.Territories[territory].Strength())
+	}
+
+	// Once the turns begin the placements are reinforcements
+	placeAllArmies(t, game)
+	territory = frontLine(game, 0)
+	strength, reserves := game.Territories[territory].Strength(), game.Reserves[0]
+	if err := game.PlaceArmies(0, map[string]int{territory: reserves}); err != nil {
+		t.Fatal("Unexpected error placing reinforcements:", err)
+	}
+	if game.Territories[territory].Strength() != strength+reserves || game.Reserves[0] != 0 {
+		t.Errorf("Expected every reinforcement on %s, got strength %d", territory, game.Territories[territory].Strength())
+	}
+}
@@ -0,0 +1,180 @@
+package game
+
+import (
+	"sort"
+)
+
+// ClaimPhase is the phase of a draft setup in which the players take turns claiming the unowned territories
+const ClaimPhase Phase = "claim"
+
+// startingArmies is the number of infantry each player starts with on the classic map, keyed by the number of players
+var startingArmies = map[int]int{
+	3: 35,
+	4: 30,
+	5: 25,
+	6: 20,
+}
+
+// classicTerritories is the number of territories on the classic map
+const classicTerritories = 42
+
+// StartingArmies returns the number of infantry each player starts the game with on a map with the given number of territories
+// Maps with more territories than the classic map hand out more armies in proportion, so there are always enough
+// armies to go round every territory
+func StartingArmies(numPlayers, numTerritories int) int {
+	armies := startingArmies[numPlayers]
+	if numTerritories > classicTerritories {
+		armies = (armies*numTerritories + classicTerritories - 1) / classicTerritories
+	}
+	return armies
+}
+
+// setUp shuffles the cards, hands each player their starting armies and begins the initial placement of armies
+func (g *Game) setUp() {
+	g.Reserves = make(map[int]int)
+	for _, p := range g.Players {
+		g.Reserves[p.ID] = StartingArmies(len(g.Players), len(g.Territories))
+	}
+
+	g.CurrentPlayer = 0
+	g.PlacementRound = 1
+
+	g.shuffleCards()
+
+	if g.Options.Mode == SecretMission {
+		g.dealMissions()
+	}
+
+	switch g.Options.Setup {
+	case DraftSetup:
+		if g.neutral() != nil {
+			g.dealNeutralTerritories()
+		}
+		g.Phase = ClaimPhase
+	default:
+		g.dealTerritories()
+		g.Phase = PlacementPhase
+	}
+
+	// The neutral player never takes a turn, so its armies are all placed up front
+	if g.neutral() != nil {
+		g.placeNeutralArmies()
+	}
+}
+
+// dealTerritories deals the territories out to the players at random
+// Each player places one of their starting armies on each territory they are dealt
+func (g *Game) dealTerritories() {
+	// Sort the territory names before shuffling so the deal only depends on the game's source of randomness
+	names := make([]string, 0, len(g.Territories))
+	for name := range g.Territories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	g.random().Shuffle(len(names), func(i, j int) {
+		names[i], names[j] = names[j], names[i]
+	})
+
+	for i, name := range names {
+		g.occupy(g.Territories[name], &g.Players[i%len(g.Players)])
+	}
+}
+
+// Claim claims an unowned territory for the player during a draft setup
+func (g *Game) Claim(playerID int, territory string) error {
+	if err := g.checkTurn(playerID, ClaimPhase); err != nil {
+		return err
+	}
+	t, ok := g.Territories[territory]
+	if !ok {
+		return &UnknownTerritoryError{Name: territory}
+	}
+	if t.OwnedBy != nil {
+		return &TerritoryAlreadyOwnedError{Territory: territory, OwnerID: t.OwnedBy.ID}
+	}
+	if err := g.checkStartingArmiesLeft(playerID); err != nil {
+		return err
+	}
+
+	g.record(&Claimed{PlayerID: playerID, Territory: territory})
+	g.occupy(t, &g.Players[playerID])
+
+	// Once every territory has been claimed the players carry on placing their remaining armies
+	if g.unclaimedTerritories() == 0 {
+		g.Phase = PlacementPhase
+	}
+	g.nextPlacement()
+	return nil
+}
+
+// Place places one of the player's starting armies on a territory they own
+func (g *Game) Place(playerID int, territory string) error {
+	if err := g.checkTurn(playerID, PlacementPhase); err != nil {
+		return err
+	}
+	t, ok := g.Territories[territory]
+	if !ok {
+		return &UnknownTerritoryError{Name: territory}
+	}
+	if !t.isOwnedBy(playerID) {
+		return &TerritoryNotOwnedError{Territory: territory, PlayerID: playerID}
+	}
+	if err := g.checkStartingArmiesLeft(playerID); err != nil {
+		return err
+	}
+
+	g.record(&Placed{PlayerID: playerID, Territory: territory})
+	t.AddArmies(1)
+	g.Reserves[playerID]--
+	g.nextPlacement()
+	return nil
+}
+
+// checkStartingArmiesLeft returns an error if the player has none of their starting armies left to place
+func (g *Game) checkStartingArmiesLeft(playerID int) error {
+	if g.Reserves[playerID] <= 0 {
+		return &TooManyArmiesError{Armies: 1, Available: g.Reserves[playerID]}
+	}
+	return nil
+}
+
+// occupy hands an unowned territory to a player, who places one of their starting armies on it
+func (g *Game) occupy(t *Territory, p *Player) {
+	t.OwnedBy = p
+	t.AddArmies(1)
+	g.Reserves[p.ID]--
+}
+
+func (g *Game) unclaimedTerritories() int {
+	unclaimed := 0
+	for _, t := range g.Territories {
+		if t.OwnedBy == nil {
+			unclaimed++
+		}
+	}
+	return unclaimed
+}
+
+// nextPlacement passes the placement of armies to the next player with armies left to place
+// Once every player has placed all of their starting armies the first turn begins,
+// unless the players must first designate their capitals
+func (g *Game) nextPlacement() {
+	for i := 1; i <= len(g.Players); i++ {
+		next := (g.CurrentPlayer + i) % len(g.Players)
+		if g.Reserves[next] > 0 {
+			// Passing the first player means a new round of placement has started
+			if next <= g.CurrentPlayer {
+				g.PlacementRound++
+			}
+			g.CurrentPlayer = next
+			return
+		}
+	}
+
+	if g.Options.Mode == CapitalRisk {
+		g.CurrentPlayer = 0
+		g.Phase = CapitalPhase
+		return
+	}
+	g.beginTurns()
+}
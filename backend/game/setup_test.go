@@ -0,0 +1,203 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+)
+
+func TestStartingArmies(t *testing.T) {
+	want := map[int]int{3: 35, 4: 30, 5: 25, 6: 20}
+	for numPlayers, armies := range want {
+		if StartingArmies(numPlayers, 42) != armies {
+			t.Errorf("Expected %d starting armies for %d players, got: %d", armies, numPlayers, StartingArmies(numPlayers, 42))
+		}
+		if StartingArmies(numPlayers, 6) != armies {
+			t.Errorf("Expected %d starting armies for %d players on a small map, got: %d", armies, numPlayers, StartingArmies(numPlayers, 6))
+		}
+	}
+	if StartingArmies(3, 120) != 100 {
+		t.Errorf("Expected 100 starting armies for 3 players on a map of 120 territories, got: %d", StartingArmies(3, 120))
+	}
+}
+
+func TestLargeMapSetup(t *testing.T) {
+	// A line of 120 territories, far more than 3 players could cover with the classic starting armies
+	m := &Map{Name: "line", Continents: []Continent{{Name: "Line", Bonus: 10}}}
+	for i := 0; i < 120; i++ {
+		links := []string{}
+		if i > 0 {
+			links = append(links, fmt.Sprintf("T%d", i-1))
+		}
+		if i < 119 {
+			links = append(links, fmt.Sprintf("T%d", i+1))
+		}
+		m.Territories = append(m.Territories, MapTerritory{Name: fmt.Sprintf("T%d", i), Continent: "Line", Links: links})
+	}
+	m.Cards = deck(m.Territories)
+	RegisterMap(m)
+
+	game := newTestGame(t, Options{Map: "line"})
+	for _, p := range game.Players {
+		if game.Reserves[p.ID] != 100-40 {
+			t.Errorf("Expected player %d to have %d armies left to place, got: %d", p.ID, 100-40, game.Reserves[p.ID])
+		}
+	}
+
+	game = newTestGame(t, Options{Map: "line", Setup: DraftSetup})
+	for i := 0; i < 120; i++ {
+		if err := game.Claim(game.CurrentPlayer, fmt.Sprintf("T%d", i)); err != nil {
+			t.Fatalf("Unexpected error claiming T%d: %s", i, err)
+		}
+	}
+	if game.Phase != PlacementPhase || game.unclaimedTerritories() != 0 {
+		t.Errorf("Expected every territory to be claimed, got %d unclaimed in the %q phase", game.unclaimedTerritories(), game.Phase)
+	}
+}
+
+func TestRandomSetup(t *testing.T) {
+	game := newTestGame(t, Options{})
+
+	if game.Phase != PlacementPhase {
+		t.Errorf("Expected a random setup to start in the %q phase. Got: %q", PlacementPhase, game.Phase)
+	}
+
+	// Each of the three players is dealt 14 of the 42 territories, with one army on each
+	owned := make(map[int]int)
+	for _, territory := range game.Territories {
+		if territory.OwnedBy == nil {
+			t.Fatalf("%q territory was not dealt to anyone", territory.Name)
+		}
+		if territory.Armies[Infantry] != 1 {
+			t.Errorf("%q territory should start with one infantry. Got: %d", territory.Name, territory.Armies[Infantry])
+		}
+		owned[territory.OwnedBy.ID]++
+	}
+	for _, p := range game.Players {
+		if owned[p.ID] != 14 {
+			t.Errorf("Expected player %d to be dealt 14 territories. Got: %d", p.ID, owned[p.ID])
+		}
+		if game.Reserves[p.ID] != 35-14 {
+			t.Errorf("Expected player %d to have %d armies left to place. Got: %d", p.ID, 35-14, game.Reserves[p.ID])
+		}
+	}
+
+	placeAllArmies(t, game)
+
+	if game.Phase != ReinforcePhase || game.CurrentPlayer != 0 || game.TurnNumber != 1 {
+		t.Errorf("Expected turn 1 to start with player 0 once all armies are placed. Got: turn %d, player %d, phase %q", game.TurnNumber, game.CurrentPlayer, game.Phase)
+	}
+	if game.PlacementRound != 21 {
+		t.Errorf("Expected 21 rounds of placement. Got: %d", game.PlacementRound)
+	}
+	for _, p := range game.Players {
+		total := 0
+		for _, territory := range game.Territories {
+			if territory.isOwnedBy(p.ID) {
+				total += territory.Armies[Infantry]
+			}
+		}
+		if total != 35 {
+			t.Errorf("Expected player %d to have 35 armies on the board. Got: %d", p.ID, total)
+		}
+	}
+}
+
+func TestDraftSetup(t *testing.T) {
+	game := newTestGame(t, Options{Setup: DraftSetup})
+
+	if game.Phase != ClaimPhase {
+		t.Fatalf("Expected a draft setup to start in the %q phase. Got: %q", ClaimPhase, game.Phase)
+	}
+
+	var notYourTurn *NotYourTurnError
+	if err := game.Claim(1, "Alaska"); !errors.As(err, &notYourTurn) {
+		t.Errorf("Expected a NotYourTurnError when player 1 claims first, got: %v", err)
+	}
+	var unknownTerritory *UnknownTerritoryError
+	if err := game.Claim(0, "Atlantis"); !errors.As(err, &unknownTerritory) {
+		t.Errorf("Expected an UnknownTerritoryError when claiming Atlantis, got: %v", err)
+	}
+	var wrongPhase *WrongPhaseError
+	if err := game.Place(0, "Alaska"); !errors.As(err, &wrongPhase) {
+		t.Errorf("Expected a WrongPhaseError when placing before every territory is claimed, got: %v", err)
+	}
+
+	if err := game.Claim(0, "Alaska"); err != nil {
+		t.Fatal("Unexpected error claiming Alaska:", err)
+	}
+	var alreadyOwned *TerritoryAlreadyOwnedError
+	if err := game.Claim(1, "Alaska"); !errors.As(err, &alreadyOwned) {
+		t.Errorf("Expected a TerritoryAlreadyOwnedError when claiming Alaska twice, got: %v", err)
+	}
+
+	// Claim the remaining territories in alphabetical order
+	names := make([]string, 0, len(game.Territories))
+	for name := range game.Territories {
+		if name != "Alaska" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := game.Claim(game.CurrentPlayer, name); err != nil {
+			t.Fatalf("Unexpected error claiming %q: %s", name, err)
+		}
+	}
+
+	if game.Phase != PlacementPhase {
+		t.Errorf("Expected the %q phase once every territory is claimed. Got: %q", PlacementPhase, game.Phase)
+	}
+
+	// Alaska was claimed by player 0, so player 0 can't place on the territory claimed straight after it
+	var notOwned *TerritoryNotOwnedError
+	if err := game.Place(0, names[0]); !errors.As(err, &notOwned) {
+		t.Errorf("Expected a TerritoryNotOwnedError when placing on another player's territory, got: %v", err)
+	}
+
+	placeAllArmies(t, game)
+
+	if game.Phase != ReinforcePhase {
+		t.Errorf("Expected the %q phase once all armies are placed. Got: %q", ReinforcePhase, game.Phase)
+	}
+}
+
+func TestPlaceWithoutArmies(t *testing.T) {
+	game := newTestGame(t, Options{})
+	game.Reserves[0] = 0
+	territory := frontLine(game, 0)
+
+	var tooMany *TooManyArmiesError
+	if err := game.Place(0, territory); !errors.As(err, &tooMany) {
+		t.Errorf("Expected a TooManyArmiesError placing with no armies left, got: %v", err)
+	}
+	if game.Reserves[0] != 0 || game.Territories[territory].Strength() != 1 {
+		t.Errorf("Expected nothing to be placed, got %d armies left and %d on %q", game.Reserves[0], game.Territories[territory].Strength(), territory)
+	}
+
+	game = newTestGame(t, Options{Setup: DraftSetup})
+	game.Reserves[0] = 0
+	if err := game.Claim(0, "Alaska"); !errors.As(err, &tooMany) {
+		t.Errorf("Expected a TooManyArmiesError claiming with no armies left, got: %v", err)
+	}
+}
+
+// placeAllArmies has each player place their remaining starting armies on the first territory they own
+func placeAllArmies(t *testing.T, game *Game) {
+	for game.Phase == PlacementPhase {
+		placed := false
+		for name, territory := range game.Territories {
+			if territory.isOwnedBy(game.CurrentPlayer) {
+				if err := game.Place(game.CurrentPlayer, name); err != nil {
+					t.Fatalf("Unexpected error placing an army on %q: %s", name, err)
+				}
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			t.Fatalf("Player %d owns no territories to place armies on", game.CurrentPlayer)
+		}
+	}
+}
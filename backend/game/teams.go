@@ -0,0 +1,79 @@
+package game
+
+// validateTeams checks that the players are split into at least two teams of the same size
+func validateTeams(players []Player) error {
+	teams := make(map[int]int)
+	for _, p := range players {
+		teams[p.TeamID]++
+	}
+	if len(teams) < 2 {
+		return &InvalidTeamsError{Teams: teams}
+	}
+	for _, size := range teams {
+		if size != len(players)/len(teams) || len(players)%len(teams) != 0 {
+			return &InvalidTeamsError{Teams: teams}
+		}
+	}
+	return nil
+}
+
+// isTeammate reports whether the two players play on the same team in a team game
+// A player is never their own teammate
+func (g *Game) isTeammate(playerID, otherID int) bool {
+	if !g.Options.Teams || playerID == otherID {
+		return false
+	}
+	if playerID < 0 || playerID >= len(g.Players) || otherID < 0 || otherID >= len(g.Players) {
+		return false
+	}
+	return g.Players[playerID].TeamID == g.Players[otherID].TeamID
+}
+
+// isAllied reports whether the territory is owned by the player or one of their teammates
+func (g *Game) isAllied(t *Territory, playerID int) bool {
+	return t.isOwnedBy(playerID) || (t.OwnedBy != nil && g.isTeammate(playerID, t.OwnedBy.ID))
+}
+
+// team returns the IDs of the players on the winners' teams, so that teammates win together
+func (g *Game) team(winners []int) []int {
+	if !g.Options.Teams {
+		return winners
+	}
+	team := []int{}
+	for _, p := range g.Players {
+		for _, winner := range winners {
+			if p.ID == winner || g.isTeammate(p.ID, winner) {
+				team = append(team, p.ID)
+				break
+			}
+		}
+	}
+	return team
+}
+
+// TransferCards gives some of the player's cards to a teammate during the player's reinforce phase
+func (g *Game) TransferCards(playerID, teammateID int, cards []Card) error {
+	if err := g.checkTurn(playerID, ReinforcePhase); err != nil {
+		return err
+	}
+	if !g.Options.CardTransfers {
+		return &CardTransfersNotAllowedError{}
+	}
+	if !g.isTeammate(playerID, teammateID) {
+		return &NotTeammateError{PlayerID: playerID, OtherID: teammateID}
+	}
+
+	// Make sure the player holds every card before giving any of them away
+	hand := append([]Card{}, g.Cards.OwnedBy[playerID]...)
+	for _, card := range cards {
+		i := indexOfCard(hand, card)
+		if i < 0 {
+			return &CardNotOwnedError{Card: card, PlayerID: playerID}
+		}
+		hand = append(hand[:i], hand[i+1:]...)
+	}
+	g.record(&CardsTransferred{PlayerID: playerID, TeammateID: teammateID, Cards: append([]Card{}, cards...)})
+	g.Cards.OwnedBy[playerID] = hand
+	g.Cards.OwnedBy[teammateID] = append(g.Cards.OwnedBy[teammateID], cards...)
+	return nil
+}
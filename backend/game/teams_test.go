@@ -0,0 +1,148 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+// newTeamGame returns a six-player game of three teams in player 0's attack phase
+// Players 0 and 3 form team 0, players 1 and 4 team 1, and players 2 and 5 team 2
+// Player 0 owns Alaska, player 3 owns Northwest Territory, and player 1 owns every other territory
+func newTeamGame(t *testing.T, options Options) *Game {
+	players := []Player{}
+	for i := 0; i < 6; i++ {
+		players = append(players, Player{ID: i, Name: string(rune('A' + i)), TeamID: i % 3})
+	}
+	options.Teams = true
+	game, err := NewGame("Team Game", players, options)
+	if err != nil {
+		t.Fatal("Unexpected error while building new game:", err)
+	}
+
+	for name, territory := range game.Territories {
+		territory.OwnedBy = &game.Players[1]
+		territory.Armies = map[Army]int{Infantry: 1, Cavalry: 0, Artillery: 0}
+		switch name {
+		case "Alaska":
+			territory.OwnedBy = &game.Players[0]
+			territory.Armies[Infantry] = 5
+		case "Northwest Territory":
+			territory.OwnedBy = &game.Players[3]
+		}
+	}
+	game.beginTurns()
+	game.Reserves = map[int]int{0: 0, 1: 0, 2: 0, 3: 0, 4: 0, 5: 0}
+	game.Phase = AttackPhase
+	game.SetDice(&fixedDice{rolls: []int{6, 1}})
+	return game
+}
+
+func TestValidateTeams(t *testing.T) {
+	testCases := []struct {
+		name  string
+		teams []int
+		valid bool
+	}{
+		{name: "ThreeTeamsOfTwo", teams: []int{0, 1, 2, 0, 1, 2}, valid: true},
+		{name: "TwoTeamsOfThree", teams: []int{0, 0, 0, 1, 1, 1}, valid: true},
+		{name: "OneTeam", teams: []int{0, 0, 0, 0}, valid: false},
+		{name: "Uneven", teams: []int{0, 0, 0, 1, 1}, valid: false},
+	}
+
+	var invalidTeams *InvalidTeamsError
+	for _, testCase := range testCases {
+		players := []Player{}
+		for i, team := range testCase.teams {
+			players = append(players, Player{ID: i, TeamID: team})
+		}
+		_, err := NewGame(testCase.name, players, Options{Teams: true})
+		if testCase.valid && err != nil {
+			t.Errorf("%s: Unexpected error creating the game: %s", testCase.name, err)
+		}
+		if !testCase.valid && !errors.As(err, &invalidTeams) {
+			t.Errorf("%s: Expected an InvalidTeamsError, got: %v", testCase.name, err)
+		}
+	}
+}
+
+func TestTeamAttack(t *testing.T) {
+	game := newTeamGame(t, Options{})
+
+	var attackTeammate *AttackTeammateError
+	if _, err := game.Attack(0, "Alaska", "Northwest Territory", 1); !errors.As(err, &attackTeammate) {
+		t.Errorf("Expected an AttackTeammateError attacking a teammate, got: %v", err)
+	}
+	if _, err := game.Attack(0, "Alaska", "Alberta", 1); err != nil {
+		t.Errorf("Unexpected error attacking another team: %s", err)
+	}
+}
+
+func TestTeamFortify(t *testing.T) {
+	game := newTeamGame(t, Options{})
+	game.Territories["Greenland"].OwnedBy = &game.Players[0]
+
+	// Alaska reaches Greenland through player 3's Northwest Territory
+	if err := game.Fortify(0, "Alaska", "Greenland", 2); err != nil {
+		t.Fatal("Unexpected error fortifying through a teammate's territory:", err)
+	}
+	if game.Territories["Greenland"].Strength() != 3 {
+		t.Errorf("Expected Greenland to hold 3 armies, got: %d", game.Territories["Greenland"].Strength())
+	}
+}
+
+func TestTeamVictory(t *testing.T) {
+	game := newTeamGame(t, Options{})
+	for _, id := range []int{2, 4, 5} {
+		game.Eliminations = append(game.Eliminations, Elimination{PlayerID: id, Order: len(game.Eliminations) + 1})
+	}
+
+	// Conquering Alberta leaves player 1 with territories, so the game carries on
+	if _, err := game.Attack(0, "Alaska", "Alberta", 1); err != nil {
+		t.Fatal("Unexpected error attacking:", err)
+	}
+	if game.Phase == GameOverPhase {
+		t.Fatal("Expected the game to carry on while player 1 holds territories")
+	}
+	if err := game.Occupy(0, 1); err != nil {
+		t.Fatal("Unexpected error occupying Alberta:", err)
+	}
+
+	for name, territory := range game.Territories {
+		if territory.isOwnedBy(1) && name != "Kamchatka" {
+			territory.OwnedBy = &game.Players[3]
+		}
+	}
+	if _, err := game.Attack(0, "Alaska", "Kamchatka", 1); err != nil {
+		t.Fatal("Unexpected error attacking:", err)
+	}
+	if game.Phase != GameOverPhase || len(game.Winners) != 2 || game.Winners[0] != 0 || game.Winners[1] != 3 {
+		t.Errorf("Expected players 0 and 3 to win together, got: phase %q, winners %v", game.Phase, game.Winners)
+	}
+}
+
+func TestTransferCards(t *testing.T) {
+	alaska := Card{Territory: "Alaska", ArmyType: Infantry}
+	congo := Card{Territory: "Congo", ArmyType: Infantry}
+
+	game := newTeamGame(t, Options{})
+	game.Phase = ReinforcePhase
+	game.Cards.OwnedBy[0] = []Card{alaska, congo}
+	var notAllowed *CardTransfersNotAllowedError
+	if err := game.TransferCards(0, 3, []Card{alaska}); !errors.As(err, &notAllowed) {
+		t.Errorf("Expected a CardTransfersNotAllowedError without the cardTransfers option, got: %v", err)
+	}
+
+	game = newTeamGame(t, Options{CardTransfers: true})
+	game.Phase = ReinforcePhase
+	game.Cards.OwnedBy[0] = []Card{alaska, congo}
+	var notTeammate *NotTeammateError
+	if err := game.TransferCards(0, 1, []Card{alaska}); !errors.As(err, &notTeammate) {
+		t.Errorf("Expected a NotTeammateError giving cards to another team, got: %v", err)
+	}
+	if err := game.TransferCards(0, 3, []Card{alaska}); err != nil {
+		t.Fatal("Unexpected error giving cards to a teammate:", err)
+	}
+	if len(game.Cards.OwnedBy[0]) != 1 || len(game.Cards.OwnedBy[3]) != 1 || game.Cards.OwnedBy[3][0] != alaska {
+		t.Errorf("Expected Alaska to pass to player 3, got: %v and %v", game.Cards.OwnedBy[0], game.Cards.OwnedBy[3])
+	}
+}
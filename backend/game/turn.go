@@ -0,0 +1,120 @@
+package game
+
+type Phase string
+
+const (
+	PlacementPhase Phase = "placement"
+	ReinforcePhase Phase = "reinforce"
+	AttackPhase    Phase = "attack"
+	FortifyPhase   Phase = "fortify"
+	GameOverPhase  Phase = "gameOver"
+)
+
+// nextPhase maps each phase of a player's turn to the phase that follows it
+// The fortify phase has no entry since it is followed by the next player's reinforce phase
+var nextPhase = map[Phase]Phase{
+	ReinforcePhase: AttackPhase,
+	AttackPhase:    FortifyPhase,
+}
+
+// checkTurn returns an error unless it is the given player's turn and the game is in one of the given phases
+func (g *Game) checkTurn(playerID int, phases ...Phase) error {
+	if playerID < 0 || playerID >= len(g.Players) {
+		return &UnknownPlayerError{ID: playerID}
+	}
+	if g.Phase == GameOverPhase {
+		return &GameOverError{}
+	}
+	if playerID != g.CurrentPlayer {
+		return &NotYourTurnError{PlayerID: playerID, CurrentPlayer: g.CurrentPlayer}
+	}
+	for _, phase := range phases {
+		if g.Phase == phase {
+			return nil
+		}
+	}
+	return &WrongPhaseError{Phase: g.Phase, Allowed: phases}
+}
+
+// EndPhase moves the current player on to the next phase of their turn
+// Ending the fortify phase ends the player's turn
+func (g *Game) EndPhase(playerID int) error {
+	if err := g.checkTurn(playerID, ReinforcePhase, AttackPhase, FortifyPhase); err != nil {
+		return err
+	}
+	if err := g.checkNoPendingConquest(); err != nil {
+		return err
+	}
+	if err := g.checkHandSize(playerID); err != nil {
+		return err
+	}
+	if err := g.checkReinforcementsPlaced(playerID); err != nil {
+		return err
+	}
+
+	g.record(&PhaseEnded{PlayerID: playerID})
+	next, ok := nextPhase[g.Phase]
+	if !ok {
+		g.nextTurn()
+		return nil
+	}
+	g.Phase = next
+	return nil
+}
+
+// EndTurn ends the current player's turn, skipping any of their remaining phases
+func (g *Game) EndTurn(playerID int) error {
+	if err := g.checkTurn(playerID, ReinforcePhase, AttackPhase, FortifyPhase); err != nil {
+		return err
+	}
+	if err := g.checkNoPendingConquest(); err != nil {
+		return err
+	}
+	if err := g.checkHandSize(playerID); err != nil {
+		return err
+	}
+	if err := g.checkReinforcementsPlaced(playerID); err != nil {
+		return err
+	}
+	g.record(&TurnEnded{PlayerID: playerID})
+	g.nextTurn()
+	return nil
+}
+
+// beginTurns ends the initial placement of armies and starts the first player's turn
+func (g *Game) beginTurns() {
+	g.CurrentPlayer = 0
+	g.TurnNumber = 1
+	g.startTurn()
+}
+
+// nextTurn passes play to the next player
+// A player who conquered at least one territory during their turn draws a card before play passes on
+func (g *Game) nextTurn() {
+	if g.ConqueredThisTurn {
+		g.drawCard(g.CurrentPlayer)
+	}
+
+	g.CurrentPlayer = g.nextPlayer()
+	g.TurnNumber++
+	g.startTurn()
+}
+
+// startTurn starts the current player's turn at the reinforce phase and hands them their reinforcements
+func (g *Game) startTurn() {
+	g.Phase = ReinforcePhase
+	g.ConqueredThisTurn = false
+	g.reinforce()
+}
+
+// nextPlayer returns the ID of the player whose turn comes after the current player's
+// Eliminated players and the neutral player are skipped
+func (g *Game) nextPlayer() int {
+	for i := 1; i < len(g.Players); i++ {
+		next := (g.CurrentPlayer + i) % len(g.Players)
+		if !g.isEliminated(next) && !g.isNeutral(next) {
+			return next
+		}
+	}
+	return g.CurrentPlayer
+}
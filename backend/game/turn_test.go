@@ -0,0 +1,98 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+func newTestGame(t *testing.T, options Options) *Game {
+	players := []Player{
+		Player{ID: 0, Name: "Zero"},
+		Player{ID: 1, Name: "One"},
+		Player{ID: 2, Name: "Two"},
+	}
+	game, err := NewGame("Test Game", players, options)
+	if err != nil {
+		t.Fatal("Unexpected error while building new game:", err)
+	}
+	return game
+}
+
+func TestInitialTurn(t *testing.T) {
+	game := newTestGame(t, Options{})
+
+	if game.Phase != PlacementPhase {
+		t.Errorf("New game should start in the %q phase. Got: %q", PlacementPhase, game.Phase)
+	}
+	if game.CurrentPlayer != 0 {
+		t.Errorf("New game should start with player 0. Got: %d", game.CurrentPlayer)
+	}
+	if game.TurnNumber != 0 {
+		t.Errorf("New game should start at turn 0. Got: %d", game.TurnNumber)
+	}
+
+	var wrongPhase *WrongPhaseError
+	if err := game.EndPhase(0); !errors.As(err, &wrongPhase) {
+		t.Errorf("Expected a WrongPhaseError when ending the placement phase, got: %v", err)
+	}
+}
+
+func TestPhaseOrder(t *testing.T) {
+	game := newTestGame(t, Options{})
+	placeAllArmies(t, game)
+
+	if game.Phase != ReinforcePhase || game.CurrentPlayer != 0 || game.TurnNumber != 1 {
+		t.Fatalf("Expected turn 1 to start with player 0 reinforcing. Got: turn %d, player %d, phase %q", game.TurnNumber, game.CurrentPlayer, game.Phase)
+	}
+
+	placeReinforcements(t, game)
+	for _, want := range []Phase{AttackPhase, FortifyPhase} {
+		if err := game.EndPhase(0); err != nil {
+			t.Fatal("Unexpected error ending phase:", err)
+		}
+		if game.Phase != want {
+			t.Errorf("Expected phase %q, got: %q", want, game.Phase)
+		}
+	}
+
+	if err := game.EndPhase(0); err != nil {
+		t.Fatal("Unexpected error ending fortify phase:", err)
+	}
+	if game.Phase != ReinforcePhase || game.CurrentPlayer != 1 || game.TurnNumber != 2 {
+		t.Errorf("Expected turn 2 to start with player 1 reinforcing. Got: turn %d, player %d, phase %q", game.TurnNumber, game.CurrentPlayer, game.Phase)
+	}
+
+	// Ending the turn early skips the remaining phases and wraps around to the first player
+	placeReinforcements(t, game)
+	if err := game.EndTurn(1); err != nil {
+		t.Fatal("Unexpected error ending turn:", err)
+	}
+	placeReinforcements(t, game)
+	if err := game.EndTurn(2); err != nil {
+		t.Fatal("Unexpected error ending turn:", err)
+	}
+	if game.Phase != ReinforcePhase || game.CurrentPlayer != 0 || game.TurnNumber != 4 {
+		t.Errorf("Expected turn 4 to start with player 0 reinforcing. Got: turn %d, player %d, phase %q", game.TurnNumber, game.CurrentPlayer, game.Phase)
+	}
+}
+
+func TestOutOfTurn(t *testing.T) {
+	game := newTestGame(t, Options{})
+	game.beginTurns()
+
+	var notYourTurn *NotYourTurnError
+	if err := game.EndPhase(1); !errors.As(err, &notYourTurn) {
+		t.Errorf("Expected a NotYourTurnError when player 1 acts during player 0's turn, got: %v", err)
+	}
+
+	var unknownPlayer *UnknownPlayerError
+	if err := game.EndTurn(7); !errors.As(err, &unknownPlayer) {
+		t.Errorf("Expected an UnknownPlayerError for player 7, got: %v", err)
+	}
+
+	game.Phase = GameOverPhase
+	var gameOver *GameOverError
+	if err := game.EndTurn(0); !errors.As(err, &gameOver) {
+		t.Errorf("Expected a GameOverError once the game has ended, got: %v", err)
+	}
+}
@@ -0,0 +1,197 @@
+package game
+
+import (
+	"encoding/json"
+)
+
+// Type UndoRequest is a player's request to undo an action that needs the approval of every other player
+type UndoRequest struct {
+	PlayerID int `json:"playerId"`
+	// Sequence is the sequence number of the event to be undone
+	Sequence int `json:"sequence"`
+	// Approvals lists the IDs of the players who have approved the request so far
+	Approvals []int `json:"approvals"`
+}
+
+// undoPoint is the state of the game before an action, which undoing the action restores
+// The state is left out for actions that can't be undone by the end of a replay, and is rebuilt from the events
+// if it turns out to be needed after all
+type undoPoint struct {
+	sequence int
+	action   Action
+	state    []byte
+}
+
+// saveUndoPoint keeps the state of the game before the action is applied, so the action can be undone
+// Only a run of actions by the same player is kept, since once another player acts the earlier actions
+// can no longer be undone, and any pending undo request lapses
+// While replaying, the state is only kept before actions that are undone later on or can still be undone
+func (g *Game) saveUndoPoint(action Action) {
+	g.UndoRequest = nil
+	if _, ok := action.(*Created); ok {
+		return
+	}
+	if n := len(g.undoPoints); n > 0 && g.undoPoints[n-1].action.actor() != action.actor() {
+		g.undoPoints = nil
+	}
+	point := undoPoint{sequence: g.Sequence + 1, action: action}
+	if !g.replaying || g.undoable[point.sequence] {
+		state, err := json.Marshal(g)
+		if err != nil {
+			g.undoPoints = nil
+			return
+		}
+		point.state = state
+	}
+	g.undoPoints = append(g.undoPoints, point)
+}
+
+// Undo undoes the last action in the game, which must have been taken by the player
+// Actions can be undone one at a time, most recent first, until another player acts
+// A player who has just ended their turn is still the current player for undoing, so they can take back the move that
+// ended it until the next player acts, needing approval only if ending the turn drew them a card
+// Undoing an action that rolled dice or drew a card needs the approval of every other player,
+// so for those actions Undo requests the undo instead, which happens once the other players ApproveUndo
+func (g *Game) Undo(playerID int) error {
+	point, before, err := g.lastUndoPoint(playerID)
+	if err != nil {
+		return err
+	}
+
+	if g.needsApproval(point, before) {
+		g.record(&UndoRequested{PlayerID: playerID})
+		g.UndoRequest = &UndoRequest{PlayerID: playerID, Sequence: point.sequence, Approvals: []int{}}
+		return nil
+	}
+
+	g.record(&Undone{PlayerID: playerID})
+	g.restore(before)
+	return nil
+}
+
+// ApproveUndo approves the pending undo request, which is carried out once every other player has approved it
+func (g *Game) ApproveUndo(playerID int) error {
+	request, err := g.checkUndoRequest(playerID)
+	if err != nil {
+		return err
+	}
+
+	g.record(&UndoApproved{PlayerID: playerID})
+	approvals := append([]int{}, request.Approvals...)
+	if indexOf(approvals, playerID) < 0 {
+		approvals = append(approvals, playerID)
+	}
+	for _, id := range g.undoApprovers(request.PlayerID) {
+		if indexOf(approvals, id) < 0 {
+			g.UndoRequest = &UndoRequest{PlayerID: request.PlayerID, Sequence: request.Sequence, Approvals: approvals}
+			return nil
+		}
+	}
+
+	_, before, err := g.lastUndoPoint(request.PlayerID)
+	if err != nil {
+		return err
+	}
+	g.restore(before)
+	return nil
+}
+
+// RejectUndo turns down the pending undo request
+func (g *Game) RejectUndo(playerID int) error {
+	if _, err := g.checkUndoRequest(playerID); err != nil {
+		return err
+	}
+	g.record(&UndoRejected{PlayerID: playerID})
+	return nil
+}
+
+// lastUndoPoint returns the undo point for the player's last action, and the state of the game before it,
+// or an error if the player can't undo their last action
+func (g *Game) lastUndoPoint(playerID int) (*undoPoint, *Game, error) {
+	if playerID < 0 || playerID >= len(g.Players) {
+		return nil, nil, &UnknownPlayerError{ID: playerID}
+	}
+	if g.Phase == GameOverPhase {
+		return nil, nil, &GameOverError{}
+	}
+	n := len(g.undoPoints)
+	if n == 0 || g.undoPoints[n-1].action.actor() != playerID {
+		return nil, nil, &NothingToUndoError{PlayerID: playerID}
+	}
+
+	point := &g.undoPoints[n-1]
+	if point.state == nil {
+		before, err := Replay(g.Events(0)[:point.sequence-1])
+		if err != nil {
+			return nil, nil, err
+		}
+		return point, before, nil
+	}
+	var before Game
+	if err := json.Unmarshal(point.state, &before); err != nil {
+		return nil, nil, err
+	}
+	return point, &before, nil
+}
+
+// needsApproval reports whether undoing the action needs the approval of the other players
+// Rolling dice and drawing cards are down to chance, so undoing them would let a player try their luck again
+func (g *Game) needsApproval(point *undoPoint, before *Game) bool {
+	if _, ok := point.action.(*Attacked); ok {
+		return true
+	}
+	// A player who conquered a territory draws a card when their turn ends
+	return before.ConqueredThisTurn && before.TurnNumber != g.TurnNumber
+}
+
+// undoApprovers returns the IDs of the players who must approve an undo requested by the player
+// The neutral player and eliminated players have no say
+func (g *Game) undoApprovers(playerID int) []int {
+	approvers := []int{}
+	for _, p := range g.Players {
+		if p.ID != playerID && !p.Neutral && !g.isEliminated(p.ID) {
+			approvers = append(approvers, p.ID)
+		}
+	}
+	return approvers
+}
+
+// checkUndoRequest returns the pending undo request, or an error unless the player has a say in it
+func (g *Game) checkUndoRequest(playerID int) (*UndoRequest, error) {
+	if playerID < 0 || playerID >= len(g.Players) {
+		return nil, &UnknownPlayerError{ID: playerID}
+	}
+	if g.Phase == GameOverPhase {
+		return nil, &GameOverError{}
+	}
+	request := g.UndoRequest
+	if request == nil {
+		return nil, &NoUndoRequestError{}
+	}
+	if indexOf(g.undoApprovers(request.PlayerID), playerID) < 0 {
+		return nil, &NotUndoApproverError{PlayerID: playerID}
+	}
+	return request, nil
+}
+
+// restore puts the game back to the state before its last undo point, dropping the undo point
+// The game keeps its events, its sequence number and its sources of randomness
+func (g *Game) restore(before *Game) {
+	before.ID = g.ID
+	before.Sequence = g.Sequence
+	before.UndoRequest = nil
+	before.rng, before.dice = g.rng, g.dice
+	before.events, before.replaying, before.undoable = g.events, g.replaying, g.undoable
+	before.board = g.board
+	before.undoPoints = g.undoPoints[:len(g.undoPoints)-1]
+	*g = *before
+}
+
+func indexOf(ids []int, id int) int {
+	for i, other := range ids {
+		if other == id {
+			return i
+		}
+	}
+	return -1
+}
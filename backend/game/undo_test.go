@@ -0,0 +1,282 @@
+package game
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestUndoPlacement(t *testing.T) {
+	game := newTestGame(t, Options{})
+	placeAllArmies(t, game)
+	territory := frontLine(game, 0)
+	strength, reserves := game.Territories[territory].Strength(), game.Reserves[0]
+
+	if err := game.PlaceReinforcements(0, map[string]int{territory: 2}); err != nil {
+		t.Fatal("Unexpected error placing reinforcements:", err)
+	}
+
+	var nothing *NothingToUndoError
+	if err := game.Undo(1); !errors.As(err, &nothing) {
+		t.Errorf("Expected a NothingToUndoError undoing another player's action, got: %v", err)
+	}
+
+	sequence := game.Sequence
+	if err := game.Undo(0); err != nil {
+		t.Fatal("Unexpected error undoing a placement:", err)
+	}
+	if game.Territories[territory].Strength() != strength || game.Reserves[0] != reserves {
+		t.Errorf("Expected the placement to be undone, got strength %d and reserves %d", game.Territories[territory].Strength(), game.Reserves[0])
+	}
+	if game.Sequence != sequence+1 || game.UndoRequest != nil {
+		t.Errorf("Expected the undo to be recorded without needing approval, got sequence %d and request %v", game.Sequence, game.UndoRequest)
+	}
+	if events := game.Events(sequence); len(events) != 1 || events[0].Action.Type() != UndoneEvent {
+		t.Errorf("Expected an undone event, got: %v", events)
+	}
+
+	// The last of the initial placements was another player's, so there's nothing left to undo
+	if err := game.Undo(0); !errors.As(err, &nothing) {
+		t.Errorf("Expected a NothingToUndoError once the player's actions are undone, got: %v", err)
+	}
+}
+
+func TestUndoFortify(t *testing.T) {
+	game := newFortifyGame(t, AdjacentFortify)
+
+	if err := game.Fortify(0, "Alaska", "Alberta", 2); err != nil {
+		t.Fatal("Unexpected error fortifying:", err)
+	}
+	if game.CurrentPlayer == 0 {
+		t.Fatal("Expected fortifying to end the player's turn")
+	}
+
+	// The player can take back the move that ended their turn until the next player acts
+	if err := game.Undo(0); err != nil {
+		t.Fatal("Unexpected error undoing a fortify move:", err)
+	}
+	if game.Territories["Alaska"].Strength() != 5 || game.Territories["Alberta"].Strength() != 1 {
+		t.Errorf("Expected the armies to move back, got %d in Alaska and %d in Alberta",
+			game.Territories["Alaska"].Strength(), game.Territories["Alberta"].Strength())
+	}
+	if game.CurrentPlayer != 0 || game.Phase != AttackPhase || game.Reserves[1] != 0 || game.TurnNumber != 1 {
+		t.Errorf("Expected the turn to be given back, got player %d in the %q phase of turn %d", game.CurrentPlayer, game.Phase, game.TurnNumber)
+	}
+
+	// Once the next player acts, the move that ended the turn stands
+	if err := game.Fortify(0, "Alaska", "Alberta", 2); err != nil {
+		t.Fatal("Unexpected error fortifying:", err)
+	}
+	if err := game.PlaceReinforcements(1, map[string]int{frontLine(game, 1): game.Reserves[1]}); err != nil {
+		t.Fatal("Unexpected error placing reinforcements:", err)
+	}
+	var nothing *NothingToUndoError
+	if err := game.Undo(0); !errors.As(err, &nothing) {
+		t.Errorf("Expected a NothingToUndoError once the next player has acted, got: %v", err)
+	}
+}
+
+func TestUndoAttack(t *testing.T) {
+	game := newAttackGame(t, 6, 1)
+	game.Territories["Alaska"].Armies[Infantry] = 5
+	strength := game.Territories["Kamchatka"].Strength()
+
+	if _, err := game.Attack(0, "Alaska", "Kamchatka", 3); err != nil {
+		t.Fatal("Unexpected error attacking:", err)
+	}
+	conquered := game.PendingConquest != nil
+
+	// Rolling dice can only be undone with the approval of every other player
+	if err := game.Undo(0); err != nil {
+		t.Fatal("Unexpected error requesting an undo:", err)
+	}
+	if game.UndoRequest == nil || game.UndoRequest.PlayerID != 0 || game.UndoRequest.Sequence != game.Sequence-1 {
+		t.Fatalf("Expected an undo request for the attack, got: %v", game.UndoRequest)
+	}
+	if (game.PendingConquest != nil) != conquered {
+		t.Errorf("Expected the attack to stand until the undo is approved")
+	}
+
+	var approver *NotUndoApproverError
+	if err := game.ApproveUndo(0); !errors.As(err, &approver) {
+		t.Errorf("Expected a NotUndoApproverError approving your own undo, got: %v", err)
+	}
+	if err := game.ApproveUndo(1); err != nil {
+		t.Fatal("Unexpected error approving an undo:", err)
+	}
+	if game.UndoRequest == nil || len(game.UndoRequest.Approvals) != 1 {
+		t.Fatalf("Expected the undo to wait for the last approval, got: %v", game.UndoRequest)
+	}
+	if err := game.ApproveUndo(2); err != nil {
+		t.Fatal("Unexpected error approving an undo:", err)
+	}
+
+	if game.UndoRequest != nil || game.PendingConquest != nil || game.ConqueredThisTurn {
+		t.Errorf("Expected the attack to be undone, got request %v and conquest %v", game.UndoRequest, game.PendingConquest)
+	}
+	if game.Territories["Alaska"].Strength() != 5 || game.Territories["Kamchatka"].Strength() != strength || !game.Territories["Kamchatka"].isOwnedBy(1) {
+		t.Errorf("Expected the armies to be put back, got %d in Alaska and %d in Kamchatka",
+			game.Territories["Alaska"].Strength(), game.Territories["Kamchatka"].Strength())
+	}
+
+	var noRequest *NoUndoRequestError
+	if err := game.ApproveUndo(1); !errors.As(err, &noRequest) {
+		t.Errorf("Expected a NoUndoRequestError once the undo is done, got: %v", err)
+	}
+}
+
+func TestUndoCardDraw(t *testing.T) {
+	game := newAttackGame(t, 6, 1)
+	game.Territories["Alaska"].Armies[Infantry] = 5
+
+	if _, err := game.Attack(0, "Alaska", "Kamchatka", 1); err != nil {
+		t.Fatal("Unexpected error attacking:", err)
+	}
+	if err := game.Occupy(0, 1); err != nil {
+		t.Fatal("Unexpected error occupying Kamchatka:", err)
+	}
+	drawPile := append([]Card{}, game.Cards.DrawPile...)
+	if err := game.EndTurn(0); err != nil {
+		t.Fatal("Unexpected error ending the turn:", err)
+	}
+	if len(game.Cards.OwnedBy[0]) != 1 {
+		t.Fatalf("Expected a card to be drawn for conquering Kamchatka, got: %v", game.Cards.OwnedBy[0])
+	}
+
+	// Drawing a card can only be undone with the approval of every other player
+	if err := game.Undo(0); err != nil {
+		t.Fatal("Unexpected error requesting an undo:", err)
+	}
+	if game.UndoRequest == nil || game.UndoRequest.Sequence != game.Sequence-1 {
+		t.Fatalf("Expected an undo request for the end of the turn, got: %v", game.UndoRequest)
+	}
+	if events := game.Events(game.Sequence - 1); len(events) != 1 || events[0].Action.Type() != UndoRequestedEvent {
+		t.Errorf("Expected an undo requested event, got: %v", events)
+	}
+	if game.CurrentPlayer == 0 || len(game.Cards.OwnedBy[0]) != 1 {
+		t.Errorf("Expected the turn to stand until the undo is approved")
+	}
+
+	for _, id := range []int{1, 2} {
+		if err := game.ApproveUndo(id); err != nil {
+			t.Fatal("Unexpected error approving an undo:", err)
+		}
+	}
+	if game.CurrentPlayer != 0 || !game.ConqueredThisTurn || len(game.Cards.OwnedBy[0]) != 0 {
+		t.Errorf("Expected the turn to be given back without the card, got player %d with cards %v", game.CurrentPlayer, game.Cards.OwnedBy[0])
+	}
+	if !reflect.DeepEqual(game.Cards.DrawPile, drawPile) {
+		t.Errorf("Expected the card to go back to the draw pile, got: %v", game.Cards.DrawPile)
+	}
+}
+
+func TestRejectUndo(t *testing.T) {
+	game := newAttackGame(t, 1)
+	game.Territories["Alaska"].Armies[Infantry] = 5
+
+	if _, err := game.Attack(0, "Alaska", "Kamchatka", 1); err != nil {
+		t.Fatal("Unexpected error attacking:", err)
+	}
+	if err := game.Undo(0); err != nil {
+		t.Fatal("Unexpected error requesting an undo:", err)
+	}
+	if err := game.RejectUndo(2); err != nil {
+		t.Fatal("Unexpected error rejecting an undo:", err)
+	}
+	if game.UndoRequest != nil || game.Territories["Alaska"].Strength() != 4 {
+		t.Errorf("Expected the attack to stand once the undo is rejected, got request %v", game.UndoRequest)
+	}
+
+	// Taking another action withdraws the request
+	if err := game.Undo(0); err != nil {
+		t.Fatal("Unexpected error requesting an undo again:", err)
+	}
+	if _, err := game.Attack(0, "Alaska", "Kamchatka", 1); err != nil {
+		t.Fatal("Unexpected error attacking:", err)
+	}
+	var noRequest *NoUndoRequestError
+	if err := game.ApproveUndo(1); !errors.As(err, &noRequest) {
+		t.Errorf("Expected a NoUndoRequestError once the player acts again, got: %v", err)
+	}
+}
+
+func TestReplayUndo(t *testing.T) {
+	game := newTestGame(t, Options{})
+	placeAllArmies(t, game)
+	territory := frontLine(game, 0)
+
+	steps := []func() error{
+		func() error { return game.PlaceReinforcements(0, map[string]int{territory: game.Reserves[0]}) },
+		func() error { return game.Undo(0) },
+		func() error { return game.PlaceReinforcements(0, map[string]int{territory: game.Reserves[0]}) },
+		func() error { return game.EndPhase(0) },
+		func() error {
+			from, to := target(game, 0)
+			_, err := game.Attack(0, from, to, 1)
+			return err
+		},
+		func() error { return game.Undo(0) },
+		func() error { return game.RejectUndo(1) },
+		func() error { return game.Undo(0) },
+		func() error { return game.ApproveUndo(1) },
+		func() error { return game.ApproveUndo(2) },
+	}
+	for i, step := range steps {
+		if err := step(); err != nil {
+			t.Fatalf("Unexpected error at step %d: %s", i+1, err)
+		}
+	}
+
+	replayed, err := Replay(game.Events(0))
+	if err != nil {
+		t.Fatal("Unexpected error replaying the game:", err)
+	}
+	want, _ := json.Marshal(game)
+	got, _ := json.Marshal(replayed)
+	if !bytes.Equal(got, want) {
+		t.Errorf("Expected the replay to undo the same actions.\nGot:  %s\nWant: %s", got, want)
+	}
+}
+
+func TestReplayUndoPoints(t *testing.T) {
+	game := newTestGame(t, Options{})
+	placeAllArmies(t, game)
+	territory := frontLine(game, 0)
+	if err := game.PlaceReinforcements(0, map[string]int{territory: 1}); err != nil {
+		t.Fatal("Unexpected error placing reinforcements:", err)
+	}
+	if err := game.PlaceReinforcements(0, map[string]int{territory: 1}); err != nil {
+		t.Fatal("Unexpected error placing reinforcements:", err)
+	}
+
+	replayed, err := Replay(game.Events(0))
+	if err != nil {
+		t.Fatal("Unexpected error replaying the game:", err)
+	}
+	// Only the state before the last player's run of actions is worth keeping
+	kept := 0
+	for _, point := range replayed.undoPoints {
+		if point.state != nil {
+			kept++
+		}
+	}
+	if len(replayed.undoPoints) != 2 || kept != 2 {
+		t.Errorf("Expected the state to be kept before the last 2 actions, got %d undo points with %d states", len(replayed.undoPoints), kept)
+	}
+
+	// The state is rebuilt from the events if it wasn't kept
+	replayed.undoPoints[1].state = nil
+	if err := game.Undo(0); err != nil {
+		t.Fatal("Unexpected error undoing a placement:", err)
+	}
+	if err := replayed.Undo(0); err != nil {
+		t.Fatal("Unexpected error undoing a replayed placement:", err)
+	}
+	want, _ := json.Marshal(game)
+	got, _ := json.Marshal(replayed)
+	if !bytes.Equal(got, want) {
+		t.Errorf("Expected the replayed game to undo the same way.\nGot:  %s\nWant: %s", got, want)
+	}
+}
@@ -0,0 +1,170 @@
+package game
+
+import (
+	"fmt"
+)
+
+// Validate checks that the map is playable, returning an InvalidMapError listing every problem found
+// Links must be symmetric and between known territories, the territories must form a single connected board,
+// every territory must belong to a known continent, each continent must hold at least one territory and have a bonus
+// that isn't negative, and the deck must hold exactly one card per territory plus any wild cards
+// Bonuses that are merely unusual are reported by Warnings instead
+func (m *Map) Validate() error {
+	problems := []string{}
+	problemf := func(format string, a ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, a...))
+	}
+
+	if m.Name == "" {
+		problemf("Map has no name")
+	}
+
+	continents := make(map[string]int)
+	for _, c := range m.Continents {
+		if _, ok := continents[c.Name]; ok {
+			problemf("Continent %q is defined more than once", c.Name)
+		}
+		continents[c.Name] = 0
+	}
+
+	territories := make(map[string]MapTerritory)
+	for _, t := range m.Territories {
+		if t.Name == "" {
+			problemf("A territory has no name")
+			continue
+		}
+		if _, ok := territories[t.Name]; ok {
+			problemf("Territory %q is defined more than once", t.Name)
+		}
+		territories[t.Name] = t
+		if _, ok := continents[t.Continent]; !ok {
+			problemf("Territory %q belongs to unknown continent %q", t.Name, t.Continent)
+		} else {
+			continents[t.Continent]++
+		}
+	}
+	if len(territories) == 0 {
+		problemf("Map has no territories")
+	}
+
+	for _, c := range m.Continents {
+		if continents[c.Name] == 0 {
+			problemf("Continent %q has no territories", c.Name)
+			continue
+		}
+		if c.Bonus < 0 {
+			problemf("Continent %q has a negative bonus of %d", c.Name, c.Bonus)
+		}
+	}
+
+	for _, t := range m.Territories {
+		seen := make(map[string]bool)
+		for _, link := range t.Links {
+			if seen[link] {
+				problemf("Territory %q links to %q more than once", t.Name, link)
+				continue
+			}
+			seen[link] = true
+			if link == t.Name {
+				problemf("Territory %q links to itself", t.Name)
+				continue
+			}
+			other, ok := territories[link]
+			if !ok {
+				problemf("Territory %q links to unknown territory %q", t.Name, link)
+				continue
+			}
+			if !contains(other.Links, t.Name) {
+				problemf("Territory %q links to %q, but %q doesn't link back", t.Name, link, link)
+			}
+		}
+	}
+
+	if len(m.Territories) > 0 {
+		for _, name := range m.unreachable(m.Territories[0].Name) {
+			problemf("Territory %q can't be reached from %q", name, m.Territories[0].Name)
+		}
+	}
+
+	cards := make(map[string]int)
+	for _, card := range m.Cards {
+		switch card.ArmyType {
+		case Wild:
+			continue
+		case Infantry, Cavalry, Artillery:
+		default:
+			problemf("Card for %q has unknown army type %d", card.Territory, card.ArmyType)
+		}
+		if _, ok := territories[card.Territory]; !ok {
+			problemf("Card for unknown territory %q", card.Territory)
+		}
+		cards[card.Territory]++
+	}
+	for _, t := range m.Territories {
+		if cards[t.Name] != 1 {
+			problemf("Territory %q has %d cards, want 1", t.Name, cards[t.Name])
+		}
+	}
+
+	if len(problems) > 0 {
+		return &InvalidMapError{Map: m.Name, Problems: problems}
+	}
+	return nil
+}
+
+// Warnings lists the parts of the map that are playable but unusual, which Validate lets through
+// Continents usually have a bonus between 1 and their number of territories
+func (m *Map) Warnings() []string {
+	territories := make(map[string]int)
+	for _, t := range m.Territories {
+		territories[t.Continent]++
+	}
+
+	warnings := []string{}
+	for _, c := range m.Continents {
+		if territories[c.Name] > 0 && (c.Bonus == 0 || c.Bonus > territories[c.Name]) {
+			warnings = append(warnings, fmt.Sprintf("Continent %q has a bonus of %d, usually between 1 and its %d territories", c.Name, c.Bonus, territories[c.Name]))
+		}
+	}
+	return warnings
+}
+
+// unreachable returns the territories that can't be reached from the given territory by following links,
+// in the order they appear on the map
+func (m *Map) unreachable(from string) []string {
+	links := make(map[string][]string)
+	for _, t := range m.Territories {
+		links[t.Name] = t.Links
+	}
+
+	visited := map[string]bool{from: true}
+	queue := []string{from}
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		for _, link := range links[name] {
+			if _, ok := links[link]; !ok || visited[link] {
+				continue
+			}
+			visited[link] = true
+			queue = append(queue, link)
+		}
+	}
+
+	unreachable := []string{}
+	for _, t := range m.Territories {
+		if !visited[t.Name] {
+			unreachable = append(unreachable, t.Name)
+		}
+	}
+	return unreachable
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
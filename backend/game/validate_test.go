@@ -0,0 +1,94 @@
+package game
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateClassicMap(t *testing.T) {
+	m, _ := LookupMap(ClassicMap)
+	if err := m.Validate(); err != nil {
+		t.Errorf("Expected the classic map to be valid, got: %s", err)
+	}
+
+	m, err := LoadMap(strings.NewReader(testMap))
+	if err != nil {
+		t.Fatal("Unexpected error loading the map:", err)
+	}
+	if err := m.Validate(); err != nil {
+		t.Errorf("Expected the test map to be valid, got: %s", err)
+	}
+}
+
+func TestValidateMap(t *testing.T) {
+	m := &Map{
+		Name: "broken",
+		Continents: []Continent{
+			Continent{Name: "North", Bonus: -1},
+			Continent{Name: "Empty", Bonus: 1},
+		},
+		Territories: []MapTerritory{
+			MapTerritory{Name: "A", Continent: "North", Links: []string{"B", "Z"}},
+			MapTerritory{Name: "B", Continent: "North", Links: []string{}},
+			MapTerritory{Name: "C", Continent: "South", Links: []string{"C"}},
+		},
+		Cards: []Card{
+			Card{Territory: "A", ArmyType: Infantry},
+			Card{Territory: "A", ArmyType: Cavalry},
+			Card{Territory: "C", ArmyType: Army(3)},
+			Card{Territory: WildTerritory, ArmyType: Wild},
+		},
+	}
+
+	want := []string{
+		`Territory "C" belongs to unknown continent "South"`,
+		`Continent "North" has a negative bonus of -1`,
+		`Continent "Empty" has no territories`,
+		`Territory "A" links to "B", but "B" doesn't link back`,
+		`Territory "A" links to unknown territory "Z"`,
+		`Territory "C" links to itself`,
+		`Territory "C" can't be reached from "A"`,
+		`Card for "C" has unknown army type 3`,
+		`Territory "A" has 2 cards, want 1`,
+		`Territory "B" has 0 cards, want 1`,
+	}
+
+	var invalidMap *InvalidMapError
+	if err := m.Validate(); !errors.As(err, &invalidMap) {
+		t.Fatalf("Expected an InvalidMapError, got: %v", err)
+	}
+	if len(invalidMap.Problems) != len(want) {
+		t.Fatalf("Expected %d problems, got %d: %v", len(want), len(invalidMap.Problems), invalidMap.Problems)
+	}
+	for i, problem := range want {
+		if invalidMap.Problems[i] != problem {
+			t.Errorf("Expected problem %d to be %q, got: %q", i, problem, invalidMap.Problems[i])
+		}
+	}
+}
+
+func TestMapWarnings(t *testing.T) {
+	m, err := LoadMap(strings.NewReader(testMap))
+	if err != nil {
+		t.Fatal("Unexpected error loading the map:", err)
+	}
+	if warnings := m.Warnings(); len(warnings) != 0 {
+		t.Errorf("Expected no warnings for the test map, got: %v", warnings)
+	}
+
+	// Unusual bonuses are allowed, but warned about
+	m.Continents[0].Bonus = 0
+	m.Continents[1].Bonus = 4
+	if err := m.Validate(); err != nil {
+		t.Errorf("Expected unusual bonuses to be valid, got: %s", err)
+	}
+	want := []string{
+		`Continent "North" has a bonus of 0, usually between 1 and its 3 territories`,
+		`Continent "South" has a bonus of 4, usually between 1 and its 3 territories`,
+	}
+	if warnings := m.Warnings(); !reflect.DeepEqual(warnings, want) {
+		t.Errorf("Expected the warnings %v, got: %v", want, warnings)
+	}
+}
@@ -0,0 +1,153 @@
+package game
+
+import (
+	"sort"
+)
+
+type Elimination struct {
+	PlayerID     int `json:"playerId"`
+	EliminatedBy int `json:"eliminatedBy"`
+	Turn         int `json:"turn"`
+	// Order is 1 for the first player eliminated, 2 for the second and so on
+	Order int `json:"order"`
+}
+
+// Type Standing is a player's position in the game
+type Standing struct {
+	Rank        int          `json:"rank"`
+	Player      Player       `json:"player"`
+	Territories int          `json:"territories"`
+	Armies      int          `json:"armies"`
+	Winner      bool         `json:"winner"`
+	Elimination *Elimination `json:"elimination"`
+}
+
+func (g *Game) isEliminated(playerID int) bool {
+	return g.elimination(playerID) != nil
+}
+
+func (g *Game) elimination(playerID int) *Elimination {
+	for i := range g.Eliminations {
+		if g.Eliminations[i].PlayerID == playerID {
+			return &g.Eliminations[i]
+		}
+	}
+	return nil
+}
+
+// eliminate knocks a player who has lost their last territory out of the game
+// Their cards pass to the player who eliminated them
+func (g *Game) eliminate(playerID, byID int) {
+	g.Eliminations = append(g.Eliminations, Elimination{
+		PlayerID:     playerID,
+		EliminatedBy: byID,
+		Turn:         g.TurnNumber,
+		Order:        len(g.Eliminations) + 1,
+	})
+	g.takeCards(byID, playerID)
+	g.checkVictory()
+}
+
+// checkVictory ends the game if any players have won
+func (g *Game) checkVictory() {
+	if g.Phase == GameOverPhase {
+		return
+	}
+	if winners := g.worldDominationWinners(); len(winners) > 0 {
+		g.endGame(winners)
+		return
+	}
+
+	switch g.Options.Mode {
+	case SecretMission:
+		if winners := g.missionWinners(); len(winners) > 0 {
+			g.endGame(winners)
+		}
+	case CapitalRisk:
+		if winners := g.capitalWinners(); len(winners) > 0 {
+			g.endGame(winners)
+		}
+	}
+}
+
+// worldDominationWinners returns the last player standing, once every other player has been eliminated
+// The neutral player of a two-player game doesn't need to be eliminated, and in a team game the last team standing wins
+func (g *Game) worldDominationWinners() []int {
+	active := []int{}
+	for _, p := range g.Players {
+		if !g.isEliminated(p.ID) && !p.Neutral {
+			active = append(active, p.ID)
+		}
+	}
+	if len(active) == 0 {
+		return nil
+	}
+	for _, id := range active[1:] {
+		if !g.isTeammate(active[0], id) {
+			return nil
+		}
+	}
+	return active
+}
+
+// endGame declares the winners and ends the game
+func (g *Game) endGame(winners []int) {
+	// Finish moving into a territory conquered with the winning attack
+	if conquest := g.PendingConquest; conquest != nil {
+		g.Territories[conquest.From].moveArmies(g.Territories[conquest.To], conquest.MinArmies)
+		g.PendingConquest = nil
+	}
+	g.ForcedTrade = false
+	g.Winners = g.team(winners)
+	g.Phase = GameOverPhase
+}
+
+// Standings ranks the players
+// Winners share first place, the remaining players are ranked by the territories and then armies they hold,
+// and eliminated players come last, with the most recently eliminated ranked highest
+func (g *Game) Standings() []Standing {
+	standings := []Standing{}
+	for _, p := range g.Players {
+		s := Standing{
+			Player:      p,
+			Elimination: g.elimination(p.ID),
+		}
+		for _, winner := range g.Winners {
+			if winner == p.ID {
+				s.Winner = true
+			}
+		}
+		for _, t := range g.Territories {
+			if t.isOwnedBy(p.ID) {
+				s.Territories++
+				s.Armies += t.Strength()
+			}
+		}
+		standings = append(standings, s)
+	}
+
+	sort.SliceStable(standings, func(i, j int) bool {
+		a, b := standings[i], standings[j]
+		if a.Winner != b.Winner {
+			return a.Winner
+		}
+		if (a.Elimination == nil) != (b.Elimination == nil) {
+			return a.Elimination == nil
+		}
+		if a.Elimination != nil {
+			return a.Elimination.Order > b.Elimination.Order
+		}
+		if a.Territories != b.Territories {
+			return a.Territories > b.Territories
+		}
+		return a.Armies > b.Armies
+	})
+
+	for i := range standings {
+		standings[i].Rank = i + 1
+		if standings[i].Winner {
+			standings[i].Rank = 1
+		}
+	}
+	return standings
+}
@@ -0,0 +1,104 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestElimination(t *testing.T) {
+	game := newAttackGame(t, 6, 1)
+	game.Territories["Alaska"].Armies[Infantry] = 5
+	game.Territories["Alberta"].OwnedBy = &game.Players[2]
+
+	result, err := game.Attack(0, "Alaska", "Alberta", 1)
+	if err != nil {
+		t.Fatal("Unexpected error attacking:", err)
+	}
+	if !result.Eliminated {
+		t.Error("Expected conquering player 2's last territory to eliminate them")
+	}
+	if len(game.Eliminations) != 1 {
+		t.Fatalf("Expected one elimination, got: %v", game.Eliminations)
+	}
+	want := Elimination{PlayerID: 2, EliminatedBy: 0, Turn: 1, Order: 1}
+	if game.Eliminations[0] != want {
+		t.Errorf("Expected elimination %v, got: %v", want, game.Eliminations[0])
+	}
+	if game.Phase == GameOverPhase {
+		t.Error("Expected the game to carry on while two players remain")
+	}
+
+	// Play skips the eliminated player
+	if err := game.Occupy(0, 1); err != nil {
+		t.Fatal("Unexpected error occupying Alberta:", err)
+	}
+	if err := game.EndTurn(0); err != nil {
+		t.Fatal("Unexpected error ending the turn:", err)
+	}
+	placeReinforcements(t, game)
+	if err := game.EndTurn(1); err != nil {
+		t.Fatal("Unexpected error ending the turn:", err)
+	}
+	if game.CurrentPlayer != 0 {
+		t.Errorf("Expected play to skip from player 1 to player 0, got: player %d", game.CurrentPlayer)
+	}
+}
+
+func TestWorldDomination(t *testing.T) {
+	game := newAttackGame(t, 6, 6, 1)
+	for _, territory := range game.Territories {
+		territory.OwnedBy = &game.Players[0]
+	}
+	game.Territories["Alaska"].Armies[Infantry] = 5
+	game.Territories["Alberta"].OwnedBy = &game.Players[1]
+	game.Eliminations = []Elimination{Elimination{PlayerID: 2, EliminatedBy: 0, Turn: 1, Order: 1}}
+
+	if _, err := game.Attack(0, "Alaska", "Alberta", 2); err != nil {
+		t.Fatal("Unexpected error attacking:", err)
+	}
+
+	if game.Phase != GameOverPhase {
+		t.Fatalf("Expected the game to be over, got: %q", game.Phase)
+	}
+	if len(game.Winners) != 1 || game.Winners[0] != 0 {
+		t.Errorf("Expected player 0 to win, got: %v", game.Winners)
+	}
+	if game.PendingConquest != nil || game.Territories["Alberta"].Strength() != 2 {
+		t.Errorf("Expected the winning conquest to move 2 armies into Alberta, got: %d", game.Territories["Alberta"].Strength())
+	}
+
+	var gameOver *GameOverError
+	if err := game.EndTurn(0); !errors.As(err, &gameOver) {
+		t.Errorf("Expected a GameOverError once the game is won, got: %v", err)
+	}
+
+	standings := game.Standings()
+	wantOrder := []int{0, 1, 2}
+	for i, standing := range standings {
+		if standing.Player.ID != wantOrder[i] || standing.Rank != i+1 {
+			t.Errorf("Expected player %d in position %d, got: player %d ranked %d", wantOrder[i], i+1, standing.Player.ID, standing.Rank)
+		}
+	}
+	if !standings[0].Winner || standings[0].Territories != 42 {
+		t.Errorf("Expected the winner to hold all 42 territories, got: %v", standings[0])
+	}
+	if standings[1].Elimination == nil || standings[1].Elimination.Order != 2 {
+		t.Errorf("Expected player 1 to be the second player eliminated, got: %v", standings[1].Elimination)
+	}
+}
+
+func TestStandings(t *testing.T) {
+	game := newTestGame(t, Options{})
+	giveTerritories(game, "Alaska", "Alberta")
+	game.Territories["Alaska"].OwnedBy = &game.Players[2]
+
+	// Player 1 holds the most territories, and players 0 and 2 tie on territories
+	game.Territories["Alberta"].Armies[Infantry] = 3
+	standings := game.Standings()
+	wantOrder := []int{1, 0, 2}
+	for i, standing := range standings {
+		if standing.Player.ID != wantOrder[i] || standing.Rank != i+1 {
+			t.Errorf("Expected player %d in position %d, got: player %d ranked %d", wantOrder[i], i+1, standing.Player.ID, standing.Rank)
+		}
+	}
+}
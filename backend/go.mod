@@ -1,8 +1,9 @@
 module github.com/daniel-salmon/risk
 
-go 1.13
+go 1.16
 
 require (
 	github.com/gin-gonic/gin v1.6.2
 	github.com/peterbourgon/ff/v3 v3.0.0
+	modernc.org/sqlite v1.20.4
 )
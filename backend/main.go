@@ -1,14 +1,21 @@
 package main
 
 import (
+	"bytes"
 	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/daniel-salmon/risk/game"
+	"github.com/daniel-salmon/risk/render"
 	"github.com/daniel-salmon/risk/stores"
 
 	"github.com/gin-gonic/gin"
@@ -17,18 +24,35 @@ import (
 
 var (
 	store stores.Store
+
+	// exportRoutes serve the game in formats other than JSON, so they are exempt from the JSON header checks
+	exportRoutes = map[string]bool{
+		"/game/:id/board.svg": true,
+		"/game/:id/graph.dot": true,
+	}
 )
 
 func main() {
 	var (
-		port = flag.Int("port", 8080, "Port on which to run Risk backend")
+		port      = flag.Int("port", 8080, "Port on which to run Risk backend")
+		storeType = flag.String("store", "memory", "Where to keep games: \"memory\", or \"file\" or \"sqlite\" to keep them in store-dir")
+		storeDir  = flag.String("store-dir", "games", "Directory the file and SQLite stores keep games in")
+		mapsDir   = flag.String("maps-dir", "", "Directory of map files (.json, Domination .map or Conquer Club .xml) to load alongside the classic map")
 	)
 	if err := ff.Parse(flag.CommandLine, os.Args[1:], ff.WithEnvVarNoPrefix()); err != nil {
 		log.Fatalf("Error parsing flags: %s", err)
 	}
 
+	// Load any custom maps
+	if *mapsDir != "" {
+		if err := loadMaps(*mapsDir); err != nil {
+			log.Fatalf("Error loading maps: %s", err)
+		}
+	}
+
 	// Create game store
-	store, err := stores.NewStore()
+	var err error
+	store, err = newStore(*storeType, *storeDir)
 	if err != nil {
 		log.Fatalf("Error building store: %s", err)
 	}
@@ -47,6 +71,82 @@ func main() {
 	router.Run(fmt.Sprintf(":%d", *port))
 }
 
+// newStore builds the type of store requested
+func newStore(storeType, dir string) (stores.Store, error) {
+	switch storeType {
+	case "memory":
+		return stores.NewMemoryStore(), nil
+	case "file":
+		return stores.NewFileStore(dir)
+	case "sqlite":
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, err
+		}
+		return stores.NewSQLiteStore(filepath.Join(dir, "games.db"))
+	default:
+		return nil, fmt.Errorf("Unknown store %q, want %q, %q or %q", storeType, "memory", "file", "sqlite")
+	}
+}
+
+// loadMaps registers every map in the directory
+// Maps are read from JSON map definitions, Domination .map files and Conquer Club .xml files,
+// which are named after the file they're read from
+func loadMaps(dir string) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"))
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		m, err := loadMap(path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if m == nil {
+			continue
+		}
+		if err := m.Validate(); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		for _, warning := range m.Warnings() {
+			log.Printf("%s: %s", path, warning)
+		}
+		game.RegisterMap(m)
+	}
+	return nil
+}
+
+// loadMap reads the map in the file, returning nil if the file isn't a map
+func loadMap(path string) (*game.Map, error) {
+	ext := filepath.Ext(path)
+	if ext == ".json" {
+		return game.LoadMapFile(path)
+	}
+
+	var importMap func(io.Reader, string) (*game.Import, error)
+	switch ext {
+	case ".map":
+		importMap = game.ImportDominationMap
+	case ".xml":
+		importMap = game.ImportConquerClubMap
+	default:
+		return nil, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	imp, err := importMap(f, strings.TrimSuffix(filepath.Base(path), ext))
+	if err != nil {
+		return nil, err
+	}
+	for _, unsupported := range imp.Unsupported {
+		log.Printf("%s: %s", path, unsupported)
+	}
+	return imp.Map, nil
+}
+
 func registerMiddleware(router *gin.Engine) {
 	router.HandleMethodNotAllowed = true
 
@@ -56,7 +156,7 @@ func registerMiddleware(router *gin.Engine) {
 		// will miss the one that is 'application/json', but this should be an edge case and I don't
 		// mind rejecting those requests
 		err := errors.New("Request's HTTP 'Accept' header does not match 'application/json'")
-		if c.Request.Header.Get("Accept") != "application/json" {
+		if !exportRoutes[c.FullPath()] && c.Request.Header.Get("Accept") != "application/json" {
 			c.AbortWithError(http.StatusNotAcceptable, err)
 		}
 	})
@@ -64,7 +164,7 @@ func registerMiddleware(router *gin.Engine) {
 	// Require *only* Content-Type: application/json in Header
 	router.Use(func(c *gin.Context) {
 		err := errors.New("Request's HTTP 'Content-Type' header is invalid, requires *only* 'application/json'")
-		if c.Request.Header.Get("Content-Type") != "application/json" {
+		if !exportRoutes[c.FullPath()] && c.Request.Header.Get("Content-Type") != "application/json" {
 			c.AbortWithError(http.StatusUnsupportedMediaType, err)
 		}
 	})
@@ -76,6 +176,43 @@ func setUpRoutes(router *gin.Engine) {
 
 	// Create a new game
 	router.POST("/game", newGameHandler)
+
+	// Get a game
+	router.GET("/game/:id", gameHandler)
+
+	// Take actions in a game
+	router.POST("/game/:id/placements", placementsHandler)
+	router.POST("/game/:id/attacks", attackHandler)
+	router.POST("/game/:id/conquests", conquestHandler)
+	router.POST("/game/:id/trades", tradeHandler)
+	router.POST("/game/:id/fortifications", fortificationHandler)
+	router.POST("/game/:id/capitals", capitalHandler)
+	router.POST("/game/:id/neutral-dice", neutralDiceHandler)
+	router.POST("/game/:id/card-transfers", cardTransferHandler)
+	router.POST("/game/:id/end-phase", playerActionHandler((*game.Game).EndPhase))
+	router.POST("/game/:id/end-turn", playerActionHandler((*game.Game).EndTurn))
+
+	// Draw the game's board
+	router.GET("/game/:id/board.svg", boardHandler)
+
+	// Export the game's territory graph for Graphviz
+	router.GET("/game/:id/graph.dot", graphHandler)
+
+	// List the events that make up the game
+	router.GET("/game/:id/history", historyHandler)
+
+	// Rebuild the game as it stood after any of its events
+	router.GET("/game/:id/state", stateHandler)
+
+	// Undo the player's last action, or ask the other players to approve undoing it
+	router.POST("/game/:id/undo", playerActionHandler((*game.Game).Undo))
+
+	// Approve or reject a request to undo an action
+	router.POST("/game/:id/undo/approve", playerActionHandler((*game.Game).ApproveUndo))
+	router.POST("/game/:id/undo/reject", playerActionHandler((*game.Game).RejectUndo))
+
+	// Validate a map definition
+	router.POST("/maps/validate", validateMapHandler)
 }
 
 func healthHandler(c *gin.Context) {
@@ -93,19 +230,435 @@ func newGameHandler(c *gin.Context) {
 		return
 	}
 
-	g, err := store.CreateGame(newGame.Name, newGame.Players)
+	g, err := store.CreateGame(newGame.Name, newGame.Players, newGame.Options)
 	if err != nil {
+		if isInvalidGameError(err) {
+			handleError(c, http.StatusBadRequest, err, &Error{Success: false, Message: err.Error()})
+			return
+		}
 		handleError(c, http.StatusInternalServerError, err, nil)
 		return
 	}
 
+	c.Header("Location", fmt.Sprintf("/game/%s", g.ID))
+	c.JSON(http.StatusCreated, newGameResponse(g, viewerID(c)))
+}
+
+func boardHandler(c *gin.Context) {
+	g, err := store.Get(c.Param("id"))
+	if err != nil {
+		handleGameError(c, err)
+		return
+	}
+
+	var b bytes.Buffer
+	if err := render.SVG(&b, g); err != nil {
+		handleError(c, http.StatusInternalServerError, err, nil)
+		return
+	}
+	c.Data(http.StatusOK, "image/svg+xml", b.Bytes())
+}
+
+func graphHandler(c *gin.Context) {
+	g, err := store.Get(c.Param("id"))
+	if err != nil {
+		handleGameError(c, err)
+		return
+	}
+
+	var b bytes.Buffer
+	if err := render.DOT(&b, g); err != nil {
+		handleError(c, http.StatusInternalServerError, err, nil)
+		return
+	}
+	c.Data(http.StatusOK, "text/vnd.graphviz", b.Bytes())
+}
+
+func historyHandler(c *gin.Context) {
+	events, err := store.Events(c.Param("id"))
+	if err != nil {
+		handleGameError(c, err)
+		return
+	}
+
+	// The seed is random, so it can't be guessed, but anyone holding it could replay the deal of the secret missions
+	// and the cards, so it's kept back until the game is over
+	g, err := game.Replay(events)
+	if err != nil {
+		handleError(c, http.StatusInternalServerError, err, nil)
+		return
+	}
+	if created, ok := events[0].Action.(*game.Created); ok && g.Phase != game.GameOverPhase {
+		hidden := *created
+		hidden.Seed = 0
+		events[0].Action = &hidden
+	}
+
+	// Nobody needs to know when an action was taken to the nanosecond
+	for i := range events {
+		events[i].Time = events[i].Time.Truncate(time.Second)
+	}
+
+	c.JSON(http.StatusOK, History{ID: c.Param("id"), Events: events})
+}
+
+// stateHandler rebuilds the game as it stood after the event given by the "at" query parameter,
+// or after its latest event if none is given
+func stateHandler(c *gin.Context) {
+	events, err := store.Events(c.Param("id"))
+	if err != nil {
+		handleGameError(c, err)
+		return
+	}
+
+	at := len(events)
+	if c.Query("at") != "" {
+		at, err = strconv.Atoi(c.Query("at"))
+		if err != nil || at < 1 || at > len(events) {
+			e := &Error{
+				Success: false,
+				Message: fmt.Sprintf("Query parameter %q must be an event between 1 and %d", "at", len(events)),
+			}
+			handleError(c, http.StatusBadRequest, fmt.Errorf("invalid event %q", c.Query("at")), e)
+			return
+		}
+	}
+
+	latest, err := game.Replay(events)
+	if err != nil {
+		handleError(c, http.StatusInternalServerError, err, nil)
+		return
+	}
+	g, err := game.Replay(events[:at])
+	if err != nil {
+		handleError(c, http.StatusInternalServerError, err, nil)
+		return
+	}
+	g.ID = c.Param("id")
+
+	// Once the game is over its earlier states are open to everyone, secret missions and all
+	c.JSON(http.StatusOK, buildGameResponse(g, viewerID(c), latest.Phase == game.GameOverPhase))
+}
+
+func gameHandler(c *gin.Context) {
+	g, err := store.Get(c.Param("id"))
+	if err != nil {
+		handleGameError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, newGameResponse(g, viewerID(c)))
+}
+
+// placementsHandler places armies during the claiming of territories, the initial placement of armies,
+// or the player's reinforce phase
+func placementsHandler(c *gin.Context) {
+	var placements NewPlacements
+	if !bindAction(c, &placements, "playerId", "placements") {
+		return
+	}
+	playerID := *placements.PlayerID
+	takeAction(c, func(g *game.Game) error {
+		return g.PlaceArmies(playerID, placements.Placements)
+	})
+}
+
+func attackHandler(c *gin.Context) {
+	var attack NewAttack
+	if !bindAction(c, &attack, "playerId", "from", "to") {
+		return
+	}
+	playerID := *attack.PlayerID
+	var result *game.AttackResult
+	g, ok := updateGame(c, func(g *game.Game) error {
+		var err error
+		result, err = g.Attack(playerID, attack.From, attack.To, attack.Dice)
+		return err
+	})
+	if !ok {
+		return
+	}
+	gameResponse := newGameResponse(g, spectatorID)
+	gameResponse.AttackResult = result
+	c.JSON(http.StatusOK, gameResponse)
+}
+
+// conquestHandler moves armies into the territory the player has just conquered
+func conquestHandler(c *gin.Context) {
+	var conquest NewConquest
+	if !bindAction(c, &conquest, "playerId") {
+		return
+	}
+	playerID := *conquest.PlayerID
+	takeAction(c, func(g *game.Game) error {
+		return g.Occupy(playerID, conquest.Armies)
+	})
+}
+
+func tradeHandler(c *gin.Context) {
+	var trade NewTrade
+	if !bindAction(c, &trade, "playerId", "cards") {
+		return
+	}
+	playerID := *trade.PlayerID
+	takeAction(c, func(g *game.Game) error {
+		_, err := g.TradeCards(playerID, trade.Cards)
+		return err
+	})
+}
+
+func fortificationHandler(c *gin.Context) {
+	var fortification NewFortification
+	if !bindAction(c, &fortification, "playerId", "from", "to") {
+		return
+	}
+	playerID := *fortification.PlayerID
+	takeAction(c, func(g *game.Game) error {
+		return g.Fortify(playerID, fortification.From, fortification.To, fortification.Armies)
+	})
+}
+
+// capitalHandler designates the player's capital in a capital risk game
+func capitalHandler(c *gin.Context) {
+	var capital NewCapital
+	if !bindAction(c, &capital, "playerId", "territory") {
+		return
+	}
+	playerID := *capital.PlayerID
+	takeAction(c, func(g *game.Game) error {
+		return g.DesignateCapital(playerID, capital.Territory)
+	})
+}
+
+// neutralDiceHandler sets the number of dice the player rolls for the neutral player in a two-player game
+func neutralDiceHandler(c *gin.Context) {
+	var neutralDice NewNeutralDice
+	if !bindAction(c, &neutralDice, "playerId") {
+		return
+	}
+	playerID := *neutralDice.PlayerID
+	takeAction(c, func(g *game.Game) error {
+		return g.SetNeutralDice(playerID, neutralDice.Dice)
+	})
+}
+
+// cardTransferHandler passes some of the player's cards to their teammate in a team game
+func cardTransferHandler(c *gin.Context) {
+	var transfer NewCardTransfer
+	if !bindAction(c, &transfer, "playerId", "teammateId", "cards") {
+		return
+	}
+	playerID := *transfer.PlayerID
+	takeAction(c, func(g *game.Game) error {
+		return g.TransferCards(playerID, *transfer.TeammateID, transfer.Cards)
+	})
+}
+
+// playerActionHandler returns a handler taking an action that needs nothing more than the ID of the player taking it
+func playerActionHandler(action func(g *game.Game, playerID int) error) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var playerAction PlayerAction
+		if !bindAction(c, &playerAction, "playerId") {
+			return
+		}
+		playerID := *playerAction.PlayerID
+		takeAction(c, func(g *game.Game) error {
+			return action(g, playerID)
+		})
+	}
+}
+
+// bindAction binds the request body to the action, responding with the required fields if it can't
+// NOTE: There's no authentication, so the "playerId" of an action is trusted to be the player taking it,
+// including players approving or rejecting an undo
+func bindAction(c *gin.Context, action interface{}, required ...string) bool {
+	if err := c.ShouldBindJSON(action); err != nil {
+		fields := make([]string, len(required))
+		for i, field := range required {
+			fields[i] = strconv.Quote(field)
+		}
+		e := &Error{
+			Success: false,
+			Message: fmt.Sprintf("Missing required fields %s", strings.Join(fields, ", ")),
+		}
+		handleError(c, http.StatusBadRequest, err, e)
+		return false
+	}
+	return true
+}
+
+// takeAction applies the player's action to the game and responds with the updated game
+// The response is the game as a spectator sees it, since nothing checks that the request came from the player
+func takeAction(c *gin.Context, action func(g *game.Game) error) {
+	if g, ok := updateGame(c, action); ok {
+		c.JSON(http.StatusOK, newGameResponse(g, spectatorID))
+	}
+}
+
+// updateGame applies the update to the game, responding with an error if the update fails
+func updateGame(c *gin.Context, update func(g *game.Game) error) (*game.Game, bool) {
+	g, err := store.Update(c.Param("id"), update)
+	if err != nil {
+		handleActionError(c, err)
+		return nil, false
+	}
+	return g, true
+}
+
+func validateMapHandler(c *gin.Context) {
+	var m game.Map
+	if err := c.ShouldBindJSON(&m); err != nil {
+		e := &Error{
+			Success: false,
+			Message: "Request body is not a JSON map definition",
+		}
+		handleError(c, http.StatusBadRequest, err, e)
+		return
+	}
+
+	// Report every problem with the map, rather than only the first
+	validation := MapValidation{Valid: true, Problems: []string{}, Warnings: m.Warnings()}
+	var invalidMap *game.InvalidMapError
+	if err := m.Validate(); errors.As(err, &invalidMap) {
+		validation.Valid = false
+		validation.Problems = invalidMap.Problems
+	}
+	c.JSON(http.StatusOK, validation)
+}
+
+// handleGameError responds to an error looking up a game
+func handleGameError(c *gin.Context, err error) {
+	var notFound *stores.GameNotFoundError
+	if errors.As(err, &notFound) {
+		handleError(c, http.StatusNotFound, err, &Error{Success: false, Message: err.Error()})
+		return
+	}
+	handleError(c, http.StatusInternalServerError, err, nil)
+}
+
+// handleActionError responds to an error taking an action in a game
+// Actions taken out of turn conflict with the state of the game, while actions the rules don't allow can't be processed
+func handleActionError(c *gin.Context, err error) {
+	var notFound *stores.GameNotFoundError
+	switch {
+	case errors.As(err, &notFound):
+		handleError(c, http.StatusNotFound, err, &Error{Success: false, Message: err.Error()})
+	case isOutOfTurnError(err):
+		handleError(c, http.StatusConflict, err, &Error{Success: false, Message: err.Error()})
+	case isIllegalActionError(err):
+		handleError(c, http.StatusUnprocessableEntity, err, &Error{Success: false, Message: err.Error()})
+	default:
+		handleError(c, http.StatusInternalServerError, err, nil)
+	}
+}
+
+// isOutOfTurnError reports whether the action can't be taken at this point in the game,
+// because it isn't the player's turn, the game is in another phase or something else must be done first
+func isOutOfTurnError(err error) bool {
+	targets := []interface{}{
+		new(*game.NotYourTurnError),
+		new(*game.WrongPhaseError),
+		new(*game.GameOverError),
+		new(*game.PendingConquestError),
+		new(*game.NoPendingConquestError),
+		new(*game.UnplacedArmiesError),
+		new(*game.MustTradeCardsError),
+		new(*game.NoUndoRequestError),
+	}
+	for _, target := range targets {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// isIllegalActionError reports whether the rules of the game don't allow the action
+func isIllegalActionError(err error) bool {
+	targets := []interface{}{
+		new(*game.UnknownPlayerError),
+		new(*game.UnknownTerritoryError),
+		new(*game.TerritoryNotOwnedError),
+		new(*game.TerritoryAlreadyOwnedError),
+		new(*game.AttackOwnTerritoryError),
+		new(*game.UnownedTerritoryError),
+		new(*game.AttackTeammateError),
+		new(*game.TerritoriesNotAdjacentError),
+		new(*game.TerritoriesNotConnectedError),
+		new(*game.InvalidDiceError),
+		new(*game.InsufficientArmiesError),
+		new(*game.InvalidArmiesError),
+		new(*game.TooManyArmiesError),
+		new(*game.InvalidCardSetError),
+		new(*game.CardNotOwnedError),
+		new(*game.NoNeutralPlayerError),
+		new(*game.NotTeammateError),
+		new(*game.CardTransfersNotAllowedError),
+		new(*game.NothingToUndoError),
+		new(*game.NotUndoApproverError),
+	}
+	for _, target := range targets {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// isInvalidGameError reports whether the game couldn't be created because of the players or options requested
+func isInvalidGameError(err error) bool {
+	var (
+		numPlayers *game.IncorrectNumberOfPlayersError
+		playerID   *game.PlayerIDMustMatchIndexError
+		option     *game.InvalidOptionError
+		teams      *game.InvalidTeamsError
+	)
+	return errors.As(err, &numPlayers) || errors.As(err, &playerID) || errors.As(err, &option) || errors.As(err, &teams)
+}
+
+// spectatorID is the viewer ID of anyone watching the game who isn't one of its players
+const spectatorID = -1
+
+// viewerID returns the ID of the player viewing the game, given by the "player" query parameter
+// Anyone else viewing the game is a spectator
+// NOTE: There's no authentication, so the query parameter is trusted, and anyone can ask to see
+// a player's secret mission
+func viewerID(c *gin.Context) int {
+	id, err := strconv.Atoi(c.Query("player"))
+	if err != nil {
+		return spectatorID
+	}
+	return id
+}
+
+// newGameResponse transforms the game object into the game response object for the given viewer
+// Secret missions are only shown to the player they were dealt to, until the game is over
+func newGameResponse(g *game.Game, viewerID int) GameResponse {
+	return buildGameResponse(g, viewerID, g.Phase == game.GameOverPhase)
+}
+
+// buildGameResponse transforms the game object into the game response object for the given viewer,
+// showing every player's secret mission if reveal is set
+func buildGameResponse(g *game.Game, viewerID int, reveal bool) GameResponse {
 	// Transform the game object into the game response object
 	// This removes any data stored in the keys of the game object
 	gameResponse := GameResponse{
-		Name:          g.Name,
-		GoldenCavalry: g.GoldenCavalry,
-		Players:       g.Players,
-		Territories:   []TerritoryResponse{},
+		ID:             g.ID,
+		Name:           g.Name,
+		GoldenCavalry:  g.GoldenCavalry,
+		Players:        g.Players,
+		Territories:    []TerritoryResponse{},
+		Phase:          string(g.Phase),
+		CurrentPlayer:  g.CurrentPlayer,
+		TurnNumber:     g.TurnNumber,
+		Sequence:       g.Sequence,
+		Options:        g.Options,
+		Reserves:       []ReserveResponse{},
+		PlacementRound: g.PlacementRound,
+		Reinforcement:  g.Reinforcement,
+		Winners:        []game.Player{},
+		Standings:      g.Standings(),
+		Missions:       []MissionResponse{},
+		UndoRequest:    g.UndoRequest,
 	}
 
 	// Build the territories response object
@@ -116,6 +669,8 @@ func newGameHandler(c *gin.Context) {
 			Links:     territory.Links,
 			OwnedBy:   territory.OwnedBy,
 			Armies:    []ArmyResponse{},
+			Strength:  territory.Strength(),
+			CapitalOf: g.CapitalOf(territory.Name),
 		}
 		for army, value := range territory.Armies {
 			a := ArmyResponse{
@@ -152,10 +707,36 @@ func newGameHandler(c *gin.Context) {
 		}
 	}
 
+	// Build the reserves response object in player order
+	for _, p := range g.Players {
+		r := ReserveResponse{
+			Player: p,
+			Armies: g.Reserves[p.ID],
+		}
+		gameResponse.Reserves = append(gameResponse.Reserves, r)
+	}
+
+	for _, winner := range g.Winners {
+		gameResponse.Winners = append(gameResponse.Winners, pMap[winner])
+	}
+
 	// Add the cards response to the game reponse object
 	gameResponse.Cards = cardsResponse
 
-	c.JSON(http.StatusOK, gameResponse)
+	// Build the missions response object in player order, hiding the missions the viewer shouldn't see
+	for _, p := range g.Players {
+		mission, ok := g.Missions[p.ID]
+		if !ok || (p.ID != viewerID && !reveal) {
+			continue
+		}
+		m := MissionResponse{
+			Player:  p,
+			Mission: mission,
+		}
+		gameResponse.Missions = append(gameResponse.Missions, m)
+	}
+
+	return gameResponse
 }
 
 // handleError logs the internal error encountered by the service
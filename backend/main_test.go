@@ -3,14 +3,19 @@ package main
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/daniel-salmon/risk/game"
+	"github.com/daniel-salmon/risk/stores"
 
 	"github.com/gin-gonic/gin"
 )
@@ -38,6 +43,9 @@ func newMockRouter() *gin.Engine {
 	// and additional functionality we don't want to test
 	router := gin.New()
 
+	// Start each router with an empty store
+	store = stores.NewMemoryStore()
+
 	// Register middleware
 	registerMiddleware(router)
 
@@ -118,6 +126,31 @@ func testRequest(method, url string, headers http.Header, body interface{}, stat
 	}
 }
 
+// createGame creates a new game, returning the game response
+func createGame(t *testing.T, router *gin.Engine, url string, newGame NewGame) GameResponse {
+	reqBody, err := json.Marshal(newGame)
+	if err != nil {
+		t.Fatal("Marshaling request body:", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(reqBody))
+	if err != nil {
+		t.Fatal("Creating new request:", err)
+	}
+	req.Header = happyHeaders
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected HTTP Status Code %d creating a game, got: %d", http.StatusCreated, w.Code)
+	}
+
+	var gameResponse GameResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &gameResponse); err != nil {
+		t.Fatal("Unmarshaling response body:", err)
+	}
+	return gameResponse
+}
+
 func TestHealth(t *testing.T) {
 	testCases := []struct {
 		name       string
@@ -239,13 +272,49 @@ func TestNewGame(t *testing.T) {
 			statusCode: http.StatusBadRequest,
 			expected:   Error{Success: false, Message: fmt.Sprintf("Missing required fields %q and %q", "name", "players")},
 		},
+		{
+			name:       "TooFewPlayers",
+			method:     http.MethodPost,
+			url:        "/game",
+			headers:    happyHeaders,
+			body:       NewGame{Name: "Too Few", Players: newGame.Players[:2]},
+			statusCode: http.StatusBadRequest,
+			expected:   Error{Success: false, Message: (&game.IncorrectNumberOfPlayersError{NumPlayers: 2, Min: 3, Max: 6}).Error()},
+		},
+		{
+			name:       "InvalidTeams",
+			method:     http.MethodPost,
+			url:        "/game",
+			headers:    happyHeaders,
+			body:       NewGame{Name: "One Team", Players: newGame.Players, Options: game.Options{Teams: true}},
+			statusCode: http.StatusBadRequest,
+			expected:   Error{Success: false, Message: (&game.InvalidTeamsError{Teams: map[int]int{0: 3}}).Error()},
+		},
+		{
+			name:    "Teams",
+			method:  http.MethodPost,
+			url:     "/game",
+			headers: happyHeaders,
+			body: NewGame{
+				Name: "Teams",
+				Players: []game.Player{
+					game.Player{ID: 0, Name: "Zero", TeamID: 0},
+					game.Player{ID: 1, Name: "One", TeamID: 1},
+					game.Player{ID: 2, Name: "Two", TeamID: 0},
+					game.Player{ID: 3, Name: "Three", TeamID: 1},
+				},
+				Options: game.Options{Teams: true},
+			},
+			statusCode: http.StatusCreated,
+			expected:   nil,
+		},
 		{
 			name:       "Success",
 			method:     http.MethodPost,
 			url:        "/game",
 			headers:    happyHeaders,
 			body:       newGame,
-			statusCode: http.StatusOK,
+			statusCode: http.StatusCreated,
 			expected:   nil,
 		},
 	}
@@ -266,3 +335,630 @@ func TestNewGame(t *testing.T) {
 		})
 	}
 }
+
+func TestNewGameMissions(t *testing.T) {
+	secretMission := newGame
+	secretMission.Options = game.Options{Mode: game.SecretMission}
+
+	testCases := []struct {
+		name     string
+		url      string
+		missions int
+	}{
+		{name: "Spectator", url: "/game", missions: 0},
+		{name: "Player", url: "/game?player=1", missions: 1},
+	}
+
+	router := newMockRouter()
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			gameResponse := createGame(t, router, testCase.url, secretMission)
+			if len(gameResponse.Missions) != testCase.missions {
+				t.Fatalf("Expected %d missions to be visible, got: %d", testCase.missions, len(gameResponse.Missions))
+			}
+			for _, m := range gameResponse.Missions {
+				if m.Player.ID != 1 || m.Mission == nil {
+					t.Errorf("Expected to only see player 1's mission, got: %v", m)
+				}
+			}
+		})
+	}
+}
+
+func TestActionMissions(t *testing.T) {
+	secretMission := newGame
+	secretMission.Options = game.Options{Mode: game.SecretMission}
+	router := newMockRouter()
+	gameResponse := createGame(t, router, "/game", secretMission)
+	from, _ := strongestFront(gameResponse, 0)
+
+	// Anyone can claim to be the player taking an action, so the response keeps every mission hidden
+	placement := map[string]interface{}{"playerId": 0, "placements": map[string]int{from: 1}}
+	requestJSON(t, router, http.MethodPost, "/game/"+gameResponse.ID+"/placements", placement, http.StatusOK, &gameResponse)
+	if len(gameResponse.Missions) != 0 {
+		t.Errorf("Expected no missions to be visible after an action, got: %v", gameResponse.Missions)
+	}
+}
+
+func TestValidateMap(t *testing.T) {
+	classic, _ := game.LookupMap(game.ClassicMap)
+	oneWay := game.Map{
+		Name:       "One Way",
+		Continents: []game.Continent{game.Continent{Name: "Only", Bonus: 1}},
+		Territories: []game.MapTerritory{
+			game.MapTerritory{Name: "A", Continent: "Only", Links: []string{"B"}},
+			game.MapTerritory{Name: "B", Continent: "Only", Links: []string{}},
+		},
+		Cards: []game.Card{
+			game.Card{Territory: "A", ArmyType: game.Infantry},
+			game.Card{Territory: "B", ArmyType: game.Cavalry},
+		},
+	}
+
+	testCases := []struct {
+		name       string
+		method     string
+		url        string
+		headers    http.Header
+		body       interface{}
+		statusCode int
+		expected   interface{}
+	}{
+		{
+			name:       "BadRequestBody",
+			method:     http.MethodPost,
+			url:        "/maps/validate",
+			headers:    happyHeaders,
+			body:       `{"body": "sup"}`,
+			statusCode: http.StatusBadRequest,
+			expected:   Error{Success: false, Message: "Request body is not a JSON map definition"},
+		},
+		{
+			name:       "Valid",
+			method:     http.MethodPost,
+			url:        "/maps/validate",
+			headers:    happyHeaders,
+			body:       classic,
+			statusCode: http.StatusOK,
+			expected:   MapValidation{Valid: true, Problems: []string{}, Warnings: []string{}},
+		},
+		{
+			name:       "Invalid",
+			method:     http.MethodPost,
+			url:        "/maps/validate",
+			headers:    happyHeaders,
+			body:       oneWay,
+			statusCode: http.StatusOK,
+			expected: MapValidation{
+				Valid: false,
+				Problems: []string{
+					`Territory "A" links to "B", but "B" doesn't link back`,
+				},
+				Warnings: []string{},
+			},
+		},
+	}
+
+	router := newMockRouter()
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testRequest(
+				testCase.method,
+				testCase.url,
+				testCase.headers,
+				testCase.body,
+				testCase.statusCode,
+				testCase.expected,
+				router,
+				t,
+			)
+		})
+	}
+}
+
+func TestExports(t *testing.T) {
+	router := newMockRouter()
+	id := createGame(t, router, "/game", newGame).ID
+
+	testCases := []struct {
+		name        string
+		url         string
+		statusCode  int
+		contentType string
+		prefix      string
+	}{
+		{name: "BoardNotFound", url: "/game/Atlantis/board.svg", statusCode: http.StatusNotFound, contentType: "application/json; charset=utf-8"},
+		{name: "Board", url: "/game/" + id + "/board.svg", statusCode: http.StatusOK, contentType: "image/svg+xml", prefix: "<svg"},
+		{name: "GraphNotFound", url: "/game/Atlantis/graph.dot", statusCode: http.StatusNotFound, contentType: "application/json; charset=utf-8"},
+		{name: "Graph", url: "/game/" + id + "/graph.dot", statusCode: http.StatusOK, contentType: "text/vnd.graphviz", prefix: "graph"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// The exports aren't JSON, so the JSON headers aren't required
+			req, err := http.NewRequest(http.MethodGet, testCase.url, nil)
+			if err != nil {
+				t.Fatal("Creating new request:", err)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != testCase.statusCode {
+				t.Errorf("Expected HTTP Status Code %d, got: %d", testCase.statusCode, w.Code)
+			}
+			if contentType := w.Header().Get("Content-Type"); contentType != testCase.contentType {
+				t.Errorf("Expected Content-Type %q, got: %q", testCase.contentType, contentType)
+			}
+			if !bytes.HasPrefix(w.Body.Bytes(), []byte(testCase.prefix)) {
+				t.Errorf("Expected the response to start with %q, got: %s", testCase.prefix, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestNewGameLocation(t *testing.T) {
+	router := newMockRouter()
+
+	reqBody, err := json.Marshal(newGame)
+	if err != nil {
+		t.Fatal("Marshaling request body:", err)
+	}
+	ids := make(map[string]bool)
+	for i := 0; i < 2; i++ {
+		req, err := http.NewRequest(http.MethodPost, "/game", bytes.NewReader(reqBody))
+		if err != nil {
+			t.Fatal("Creating new request:", err)
+		}
+		req.Header = happyHeaders
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var gameResponse GameResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &gameResponse); err != nil {
+			t.Fatal("Unmarshaling response body:", err)
+		}
+		if gameResponse.ID == "" || ids[gameResponse.ID] {
+			t.Errorf("Expected each game to get a new ID, got: %q", gameResponse.ID)
+		}
+		ids[gameResponse.ID] = true
+		if location := w.Header().Get("Location"); location != "/game/"+gameResponse.ID {
+			t.Errorf("Expected the Location header to point at the new game, got: %q", location)
+		}
+	}
+
+	// Creating a second game leaves the first one in the store
+	games, err := store.List()
+	if err != nil {
+		t.Fatal("Unexpected error listing games:", err)
+	}
+	if len(games) != 2 {
+		t.Errorf("Expected the store to hold 2 games, got: %d", len(games))
+	}
+}
+
+func TestNewStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "risk-main")
+	if err != nil {
+		t.Fatal("Creating temporary directory:", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if s, err := newStore("memory", ""); err != nil {
+		t.Errorf("Unexpected error building a memory store: %s", err)
+	} else if _, ok := s.(*stores.MemoryStore); !ok {
+		t.Errorf("Expected a memory store, got: %T", s)
+	}
+	if s, err := newStore("file", dir); err != nil {
+		t.Errorf("Unexpected error building a file store: %s", err)
+	} else if _, ok := s.(*stores.FileStore); !ok {
+		t.Errorf("Expected a file store, got: %T", s)
+	}
+	if s, err := newStore("sqlite", dir); err != nil {
+		t.Errorf("Unexpected error building a SQLite store: %s", err)
+	} else if _, ok := s.(*stores.SQLiteStore); !ok {
+		t.Errorf("Expected a SQLite store, got: %T", s)
+	} else {
+		s.Close()
+	}
+	if _, err := newStore("carrier-pigeon", dir); err == nil {
+		t.Error("Expected an error building an unknown store")
+	}
+}
+
+// getJSON makes a GET request with the JSON headers, checks the status code and decodes the response into v
+func getJSON(t *testing.T, router *gin.Engine, url string, statusCode int, v interface{}) {
+	requestJSON(t, router, http.MethodGet, url, nil, statusCode, v)
+}
+
+// requestJSON makes a request with the JSON headers, checks the status code and decodes the response into v
+func requestJSON(t *testing.T, router *gin.Engine, method, url string, body interface{}, statusCode int, v interface{}) {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal("Marshaling request body:", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, url, reqBody)
+	if err != nil {
+		t.Fatal("Creating new request:", err)
+	}
+	req.Header = happyHeaders
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != statusCode {
+		t.Fatalf("Expected HTTP Status Code %d from %s %s, got: %d (%s)", statusCode, method, url, w.Code, w.Body.String())
+	}
+	if v == nil {
+		return
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), v); err != nil {
+		t.Fatal("Unmarshaling response body:", err)
+	}
+}
+
+// placeArmies has the players place the given number of their starting armies, in turn
+func placeArmies(t *testing.T, id string, n int) {
+	for i := 0; i < n; i++ {
+		_, err := store.Update(id, func(g *game.Game) error {
+			for name, territory := range g.Territories {
+				if territory.OwnedBy.ID == g.CurrentPlayer {
+					return g.Place(g.CurrentPlayer, name)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal("Unexpected error placing an army:", err)
+		}
+	}
+}
+
+func TestHistory(t *testing.T) {
+	router := newMockRouter()
+	id := createGame(t, router, "/game", newGame).ID
+	placeArmies(t, id, 2)
+
+	var history struct {
+		ID     string `json:"id"`
+		Events []struct {
+			Sequence int                    `json:"sequence"`
+			Time     string                 `json:"time"`
+			Type     string                 `json:"type"`
+			Action   map[string]interface{} `json:"action"`
+		} `json:"events"`
+	}
+	getJSON(t, router, "/game/"+id+"/history", http.StatusOK, &history)
+
+	if history.ID != id || len(history.Events) != 3 {
+		t.Fatalf("Expected the game's 3 events, got: %v", history)
+	}
+	for i, wantType := range []game.EventType{game.CreatedEvent, game.PlacedEvent, game.PlacedEvent} {
+		e := history.Events[i]
+		if e.Sequence != i+1 || e.Type != string(wantType) || e.Time == "" {
+			t.Errorf("Expected event %d to be %q, got: %v", i+1, wantType, e)
+		}
+	}
+	if created, err := time.Parse(time.RFC3339Nano, history.Events[0].Time); err != nil || created.Nanosecond() != 0 {
+		t.Errorf("Expected event times to the second, got: %q", history.Events[0].Time)
+	}
+	if seed := history.Events[0].Action["seed"]; seed != float64(0) {
+		t.Errorf("Expected the seed to be hidden until the game is over, got: %v", seed)
+	}
+	if player := history.Events[2].Action["playerId"]; player != float64(1) {
+		t.Errorf("Expected player 1 to place the second army, got: %v", player)
+	}
+
+	getJSON(t, router, "/game/Atlantis/history", http.StatusNotFound, nil)
+}
+
+func TestState(t *testing.T) {
+	router := newMockRouter()
+	secretMission := newGame
+	secretMission.Options = game.Options{Mode: game.SecretMission}
+	id := createGame(t, router, "/game", secretMission).ID
+	placeArmies(t, id, 4)
+
+	testCases := []struct {
+		name          string
+		query         string
+		statusCode    int
+		sequence      int
+		currentPlayer int
+	}{
+		{name: "Latest", query: "", statusCode: http.StatusOK, sequence: 5, currentPlayer: 1},
+		{name: "Created", query: "?at=1", statusCode: http.StatusOK, sequence: 1, currentPlayer: 0},
+		{name: "Middle", query: "?at=3", statusCode: http.StatusOK, sequence: 3, currentPlayer: 2},
+		{name: "BeforeCreated", query: "?at=0", statusCode: http.StatusBadRequest},
+		{name: "AfterLatest", query: "?at=6", statusCode: http.StatusBadRequest},
+		{name: "NotANumber", query: "?at=last", statusCode: http.StatusBadRequest},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if testCase.statusCode != http.StatusOK {
+				getJSON(t, router, "/game/"+id+"/state"+testCase.query, testCase.statusCode, nil)
+				return
+			}
+			var gameResponse GameResponse
+			getJSON(t, router, "/game/"+id+"/state"+testCase.query, testCase.statusCode, &gameResponse)
+			if gameResponse.ID != id || gameResponse.Sequence != testCase.sequence || gameResponse.CurrentPlayer != testCase.currentPlayer {
+				t.Errorf("Expected event %d with player %d to place, got event %d with player %d to place",
+					testCase.sequence, testCase.currentPlayer, gameResponse.Sequence, gameResponse.CurrentPlayer)
+			}
+			if len(gameResponse.Missions) != 0 {
+				t.Errorf("Expected the missions to stay secret, got: %v", gameResponse.Missions)
+			}
+		})
+	}
+
+	// Each past state is rebuilt in full
+	var first, latest GameResponse
+	getJSON(t, router, "/game/"+id+"/state?at=1", http.StatusOK, &first)
+	getJSON(t, router, "/game/"+id+"/state", http.StatusOK, &latest)
+	armies := func(r GameResponse) int {
+		total := 0
+		for _, territory := range r.Territories {
+			total += territory.Strength
+		}
+		return total
+	}
+	if armies(latest)-armies(first) != 4 {
+		t.Errorf("Expected 4 more armies on the board than when the game was created, got: %d", armies(latest)-armies(first))
+	}
+
+	getJSON(t, router, "/game/Atlantis/state", http.StatusNotFound, nil)
+}
+
+func TestUndo(t *testing.T) {
+	router := newMockRouter()
+	id := createGame(t, router, "/game", newGame).ID
+	placeArmies(t, id, 2)
+	url := "/game/" + id + "/undo"
+
+	requestJSON(t, router, http.MethodPost, url, map[string]int{}, http.StatusBadRequest, nil)
+	requestJSON(t, router, http.MethodPost, "/game/Atlantis/undo", map[string]int{"playerId": 1}, http.StatusNotFound, nil)
+	requestJSON(t, router, http.MethodPost, url, map[string]int{"playerId": 0}, http.StatusUnprocessableEntity, nil)
+	requestJSON(t, router, http.MethodPost, url+"/approve", map[string]int{"playerId": 0}, http.StatusConflict, nil)
+
+	// Placing an army can be undone straight away
+	var gameResponse GameResponse
+	requestJSON(t, router, http.MethodPost, url, map[string]int{"playerId": 1}, http.StatusOK, &gameResponse)
+	if gameResponse.CurrentPlayer != 1 || gameResponse.Sequence != 4 || gameResponse.UndoRequest != nil {
+		t.Errorf("Expected player 1 to place their army again, got player %d at event %d", gameResponse.CurrentPlayer, gameResponse.Sequence)
+	}
+}
+
+func TestUndoApproval(t *testing.T) {
+	router := newMockRouter()
+	id := createGame(t, router, "/game", newGame).ID
+
+	// Play on until player 0 attacks
+	_, err := store.Update(id, func(g *game.Game) error {
+		for g.Phase == game.PlacementPhase {
+			for name, territory := range g.Territories {
+				if territory.OwnedBy.ID == g.CurrentPlayer {
+					if err := g.Place(g.CurrentPlayer, name); err != nil {
+						return err
+					}
+					break
+				}
+			}
+		}
+		for name, territory := range g.Territories {
+			for _, link := range territory.Links {
+				if territory.OwnedBy.ID == 0 && g.Territories[link].OwnedBy.ID != 0 {
+					if err := g.PlaceReinforcements(0, map[string]int{name: g.Reserves[0]}); err != nil {
+						return err
+					}
+					if err := g.EndPhase(0); err != nil {
+						return err
+					}
+					_, err := g.Attack(0, name, link, 1)
+					return err
+				}
+			}
+		}
+		return errors.New("player 0 has nothing to attack")
+	})
+	if err != nil {
+		t.Fatal("Unexpected error playing up to an attack:", err)
+	}
+	url := "/game/" + id + "/undo"
+
+	var gameResponse GameResponse
+	requestJSON(t, router, http.MethodPost, url, map[string]int{"playerId": 0}, http.StatusOK, &gameResponse)
+	if gameResponse.UndoRequest == nil || gameResponse.UndoRequest.PlayerID != 0 {
+		t.Fatalf("Expected undoing an attack to need approval, got: %v", gameResponse.UndoRequest)
+	}
+
+	requestJSON(t, router, http.MethodPost, url+"/approve", map[string]int{"playerId": 0}, http.StatusUnprocessableEntity, nil)
+	requestJSON(t, router, http.MethodPost, url+"/approve", map[string]int{"playerId": 1}, http.StatusOK, &gameResponse)
+	if gameResponse.UndoRequest == nil || len(gameResponse.UndoRequest.Approvals) != 1 {
+		t.Errorf("Expected the undo to wait for player 2, got: %v", gameResponse.UndoRequest)
+	}
+	requestJSON(t, router, http.MethodPost, url+"/approve", map[string]int{"playerId": 2}, http.StatusOK, &gameResponse)
+	if gameResponse.UndoRequest != nil || gameResponse.Phase != string(game.AttackPhase) {
+		t.Errorf("Expected the attack to be undone, got request: %v", gameResponse.UndoRequest)
+	}
+
+	events, _ := store.Events(id)
+	if last := events[len(events)-1].Action.Type(); last != game.UndoApprovedEvent {
+		t.Errorf("Expected the approval to be the last event, got: %q", last)
+	}
+	requestJSON(t, router, http.MethodPost, url+"/reject", map[string]int{"playerId": 1}, http.StatusConflict, nil)
+}
+
+// strongestFront returns the player's strongest territory that borders an enemy, along with an enemy territory it borders
+func strongestFront(gameResponse GameResponse, playerID int) (string, string) {
+	owners := make(map[string]int)
+	for _, territory := range gameResponse.Territories {
+		owners[territory.Name] = territory.OwnedBy.ID
+	}
+	from, to, strength := "", "", 0
+	for _, territory := range gameResponse.Territories {
+		if territory.OwnedBy.ID != playerID || territory.Strength <= strength {
+			continue
+		}
+		for _, link := range territory.Links {
+			if owners[link] != playerID {
+				from, to, strength = territory.Name, link, territory.Strength
+				break
+			}
+		}
+	}
+	return from, to
+}
+
+func TestGameActions(t *testing.T) {
+	router := newMockRouter()
+	id := createGame(t, router, "/game", newGame).ID
+	url := "/game/" + id
+
+	var gameResponse GameResponse
+	getJSON(t, router, url, http.StatusOK, &gameResponse)
+	if gameResponse.ID != id || gameResponse.Phase != string(game.PlacementPhase) {
+		t.Fatalf("Expected the new game, got: %v", gameResponse)
+	}
+	getJSON(t, router, "/game/Atlantis", http.StatusNotFound, nil)
+
+	// Place the starting armies
+	from, _ := strongestFront(gameResponse, 0)
+	placement := func(playerID int, territory string, armies int) map[string]interface{} {
+		return map[string]interface{}{"playerId": playerID, "placements": map[string]int{territory: armies}}
+	}
+	requestJSON(t, router, http.MethodPost, url+"/placements", map[string]int{"playerId": 0}, http.StatusBadRequest, nil)
+	requestJSON(t, router, http.MethodPost, url+"/placements", placement(1, from, 1), http.StatusConflict, nil)
+	requestJSON(t, router, http.MethodPost, url+"/placements", placement(0, from, 2), http.StatusUnprocessableEntity, nil)
+	requestJSON(t, router, http.MethodPost, url+"/placements", placement(0, "Atlantis", 1), http.StatusUnprocessableEntity, nil)
+	requestJSON(t, router, http.MethodPost, "/game/Atlantis/placements", placement(0, from, 1), http.StatusNotFound, nil)
+	for gameResponse.Phase == string(game.PlacementPhase) {
+		front, _ := strongestFront(gameResponse, gameResponse.CurrentPlayer)
+		requestJSON(t, router, http.MethodPost, url+"/placements", placement(gameResponse.CurrentPlayer, front, 1), http.StatusOK, &gameResponse)
+	}
+	if gameResponse.Phase != string(game.ReinforcePhase) || gameResponse.CurrentPlayer != 0 {
+		t.Fatalf("Expected player 0 to reinforce once the starting armies are placed, got player %d in the %q phase", gameResponse.CurrentPlayer, gameResponse.Phase)
+	}
+
+	// Attacking before the reinforcements are placed is out of turn
+	from, to := strongestFront(gameResponse, 0)
+	attack := map[string]interface{}{"playerId": 0, "from": from, "to": to, "dice": 3}
+	requestJSON(t, router, http.MethodPost, url+"/attacks", attack, http.StatusConflict, nil)
+
+	requestJSON(t, router, http.MethodPost, url+"/end-phase", map[string]int{"playerId": 0}, http.StatusConflict, nil)
+	reserves := gameResponse.Reserves[0].Armies
+	requestJSON(t, router, http.MethodPost, url+"/placements", placement(0, from, reserves), http.StatusOK, &gameResponse)
+
+	// The player must end their reinforce phase before attacking
+	requestJSON(t, router, http.MethodPost, url+"/attacks", attack, http.StatusConflict, nil)
+	requestJSON(t, router, http.MethodPost, url+"/end-phase", map[string]int{"playerId": 1}, http.StatusConflict, nil)
+	requestJSON(t, router, http.MethodPost, url+"/end-phase", map[string]int{"playerId": 0}, http.StatusOK, &gameResponse)
+	if gameResponse.Phase != string(game.AttackPhase) {
+		t.Fatalf("Expected ending the reinforce phase to move on to the attack phase, got: %q", gameResponse.Phase)
+	}
+	requestJSON(t, router, http.MethodPost, url+"/attacks", map[string]interface{}{"playerId": 0, "from": from, "to": to, "dice": 4}, http.StatusUnprocessableEntity, nil)
+	requestJSON(t, router, http.MethodPost, url+"/attacks", map[string]interface{}{"playerId": 0, "from": from, "to": from, "dice": 3}, http.StatusUnprocessableEntity, nil)
+	requestJSON(t, router, http.MethodPost, url+"/attacks", map[string]interface{}{"playerId": 1, "from": to, "to": from, "dice": 1}, http.StatusConflict, nil)
+	requestJSON(t, router, http.MethodPost, url+"/attacks", attack, http.StatusOK, &gameResponse)
+	if result := gameResponse.AttackResult; result == nil || result.From != from || result.To != to || len(result.AttackerRolls) != 3 {
+		t.Fatalf("Expected the attack's result, got: %v", result)
+	}
+
+	conquest := func(armies int) map[string]int { return map[string]int{"playerId": 0, "armies": armies} }
+	if gameResponse.AttackResult.Conquered {
+		requestJSON(t, router, http.MethodPost, url+"/end-turn", map[string]int{"playerId": 0}, http.StatusConflict, nil)
+		requestJSON(t, router, http.MethodPost, url+"/conquests", conquest(0), http.StatusUnprocessableEntity, nil)
+		requestJSON(t, router, http.MethodPost, url+"/conquests", conquest(3), http.StatusOK, &gameResponse)
+	} else {
+		requestJSON(t, router, http.MethodPost, url+"/conquests", conquest(3), http.StatusConflict, nil)
+	}
+
+	// Cards can only be traded during the attack phase when eliminating a player forces it
+	trade := map[string]interface{}{"playerId": 0, "cards": []game.Card{}}
+	requestJSON(t, router, http.MethodPost, url+"/trades", trade, http.StatusConflict, nil)
+
+	fortification := map[string]interface{}{"playerId": 0, "from": from, "to": "Atlantis", "armies": 1}
+	requestJSON(t, router, http.MethodPost, url+"/fortifications", fortification, http.StatusUnprocessableEntity, nil)
+
+	requestJSON(t, router, http.MethodPost, url+"/end-turn", map[string]int{"playerId": 1}, http.StatusConflict, nil)
+	requestJSON(t, router, http.MethodPost, url+"/end-turn", map[string]int{"playerId": 0}, http.StatusOK, &gameResponse)
+	if gameResponse.CurrentPlayer != 1 || gameResponse.Phase != string(game.ReinforcePhase) || gameResponse.AttackResult != nil {
+		t.Errorf("Expected player 1's turn to begin, got player %d in the %q phase", gameResponse.CurrentPlayer, gameResponse.Phase)
+	}
+
+	// Every action taken is in the game's history
+	var history History
+	getJSON(t, router, url+"/history", http.StatusOK, &history)
+	if last := history.Events[len(history.Events)-1].Action.Type(); last != game.TurnEndedEvent {
+		t.Errorf("Expected the turn to end last, got: %q", last)
+	}
+}
+
+func TestTrade(t *testing.T) {
+	router := newMockRouter()
+	id := createGame(t, router, "/game", newGame).ID
+	url := "/game/" + id
+	placeArmies(t, id, 105-42)
+
+	// Nobody holds any cards at the start of the first turn
+	cards := []game.Card{
+		{Territory: "Alaska", ArmyType: game.Infantry},
+		{Territory: "Peru", ArmyType: game.Infantry},
+		{Territory: "Egypt", ArmyType: game.Infantry},
+	}
+	trade := map[string]interface{}{"playerId": 0, "cards": cards}
+	requestJSON(t, router, http.MethodPost, url+"/trades", map[string]int{"playerId": 0}, http.StatusBadRequest, nil)
+	requestJSON(t, router, http.MethodPost, url+"/trades", trade, http.StatusUnprocessableEntity, nil)
+	requestJSON(t, router, http.MethodPost, url+"/trades", map[string]interface{}{"playerId": 0, "cards": cards[:2]}, http.StatusUnprocessableEntity, nil)
+	requestJSON(t, router, http.MethodPost, url+"/trades", map[string]interface{}{"playerId": 1, "cards": cards}, http.StatusConflict, nil)
+}
+
+func TestSetupActions(t *testing.T) {
+	router := newMockRouter()
+	capitalGame := newGame
+	capitalGame.Options = game.Options{Mode: game.CapitalRisk}
+	id := createGame(t, router, "/game", capitalGame).ID
+	url := "/game/" + id
+	placeArmies(t, id, 105-42)
+
+	var gameResponse GameResponse
+	getJSON(t, router, url, http.StatusOK, &gameResponse)
+	if gameResponse.Phase != string(game.CapitalPhase) {
+		t.Fatalf("Expected the players to designate their capitals, got the %q phase", gameResponse.Phase)
+	}
+
+	// Each player designates one of their own territories as their capital
+	capital := func(playerID int, territory string) map[string]interface{} {
+		return map[string]interface{}{"playerId": playerID, "territory": territory}
+	}
+	requestJSON(t, router, http.MethodPost, url+"/capitals", map[string]int{"playerId": 0}, http.StatusBadRequest, nil)
+	requestJSON(t, router, http.MethodPost, url+"/capitals", capital(0, "Atlantis"), http.StatusUnprocessableEntity, nil)
+	for gameResponse.Phase == string(game.CapitalPhase) {
+		territory, _ := strongestFront(gameResponse, gameResponse.CurrentPlayer)
+		requestJSON(t, router, http.MethodPost, url+"/capitals", capital(gameResponse.CurrentPlayer, territory), http.StatusOK, &gameResponse)
+	}
+	if gameResponse.Phase != string(game.ReinforcePhase) || gameResponse.CurrentPlayer != 0 {
+		t.Errorf("Expected player 0 to reinforce once the capitals are designated, got player %d in the %q phase", gameResponse.CurrentPlayer, gameResponse.Phase)
+	}
+
+	// Neutral dice and card transfers belong to two-player and team games
+	requestJSON(t, router, http.MethodPost, url+"/neutral-dice", map[string]int{"playerId": 0, "dice": 1}, http.StatusUnprocessableEntity, nil)
+	transfer := map[string]interface{}{"playerId": 0, "teammateId": 1, "cards": []game.Card{}}
+	requestJSON(t, router, http.MethodPost, url+"/card-transfers", map[string]int{"playerId": 0}, http.StatusBadRequest, nil)
+	requestJSON(t, router, http.MethodPost, url+"/card-transfers", transfer, http.StatusUnprocessableEntity, nil)
+}
+
+func TestNeutralDice(t *testing.T) {
+	router := newMockRouter()
+	twoPlayerGame := NewGame{Name: "Head to Head", Players: newGame.Players[:2], Options: game.Options{TwoPlayer: true}}
+	url := "/game/" + createGame(t, router, "/game", twoPlayerGame).ID + "/neutral-dice"
+
+	var gameResponse GameResponse
+	requestJSON(t, router, http.MethodPost, url, map[string]int{"playerId": 0, "dice": 3}, http.StatusUnprocessableEntity, nil)
+	requestJSON(t, router, http.MethodPost, url, map[string]int{"playerId": 1, "dice": 1}, http.StatusOK, &gameResponse)
+	if gameResponse.Sequence != 2 {
+		t.Errorf("Expected the neutral dice to be set, got event %d", gameResponse.Sequence)
+	}
+}
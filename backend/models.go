@@ -18,14 +18,82 @@ type Error struct {
 type NewGame struct {
 	Name    string        `json:"name" binding:"required"`
 	Players []game.Player `json:"players" binding:"required"`
+	Options game.Options  `json:"options"`
+}
+
+// Type PlayerAction identifies the player taking an action that needs nothing more
+type PlayerAction struct {
+	PlayerID *int `json:"playerId" binding:"required"`
+}
+
+type NewPlacements struct {
+	PlayerID *int `json:"playerId" binding:"required"`
+	// Placements maps territory names to the number of armies to place there
+	Placements map[string]int `json:"placements" binding:"required"`
+}
+
+type NewAttack struct {
+	PlayerID *int   `json:"playerId" binding:"required"`
+	From     string `json:"from" binding:"required"`
+	To       string `json:"to" binding:"required"`
+	Dice     int    `json:"dice"`
+}
+
+type NewConquest struct {
+	PlayerID *int `json:"playerId" binding:"required"`
+	Armies   int  `json:"armies"`
+}
+
+type NewTrade struct {
+	PlayerID *int        `json:"playerId" binding:"required"`
+	Cards    []game.Card `json:"cards" binding:"required"`
+}
+
+type NewFortification struct {
+	PlayerID *int   `json:"playerId" binding:"required"`
+	From     string `json:"from" binding:"required"`
+	To       string `json:"to" binding:"required"`
+	Armies   int    `json:"armies"`
+}
+
+type NewCapital struct {
+	PlayerID  *int   `json:"playerId" binding:"required"`
+	Territory string `json:"territory" binding:"required"`
+}
+
+type NewNeutralDice struct {
+	PlayerID *int `json:"playerId" binding:"required"`
+	// Dice is the number of dice the neutral player defends with when the player's opponent attacks it
+	Dice int `json:"dice"`
+}
+
+type NewCardTransfer struct {
+	PlayerID   *int        `json:"playerId" binding:"required"`
+	TeammateID *int        `json:"teammateId" binding:"required"`
+	Cards      []game.Card `json:"cards" binding:"required"`
 }
 
 type GameResponse struct {
-	Name          string              `json:"name"`
-	GoldenCavalry int                 `json:"goldenCavalry"`
-	Players       []game.Player       `json:"players"`
-	Cards         CardsResponse       `json:"cards"`
-	Territories   []TerritoryResponse `json:"territories"`
+	ID             string              `json:"id"`
+	Name           string              `json:"name"`
+	GoldenCavalry  int                 `json:"goldenCavalry"`
+	Players        []game.Player       `json:"players"`
+	Cards          CardsResponse       `json:"cards"`
+	Territories    []TerritoryResponse `json:"territories"`
+	Phase          string              `json:"phase"`
+	CurrentPlayer  int                 `json:"currentPlayer"`
+	TurnNumber     int                 `json:"turnNumber"`
+	Sequence       int                 `json:"sequence"`
+	Options        game.Options        `json:"options"`
+	Reserves       []ReserveResponse   `json:"reserves"`
+	PlacementRound int                 `json:"placementRound"`
+	Reinforcement  *game.Reinforcement `json:"reinforcement"`
+	Winners        []game.Player       `json:"winners"`
+	Standings      []game.Standing     `json:"standings"`
+	Missions       []MissionResponse   `json:"missions"`
+	UndoRequest    *game.UndoRequest   `json:"undoRequest"`
+	// AttackResult holds the outcome of an attack, in response to the attack
+	AttackResult *game.AttackResult `json:"attackResult"`
 }
 
 type CardsResponse struct {
@@ -45,9 +113,34 @@ type TerritoryResponse struct {
 	Links     []string       `json:"links"`
 	OwnedBy   *game.Player   `json:"ownedBy"`
 	Armies    []ArmyResponse `json:"armies"`
+	Strength  int            `json:"strength"`
+	CapitalOf *game.Player   `json:"capitalOf"`
+}
+
+type ReserveResponse struct {
+	Player game.Player `json:"player"`
+	Armies int         `json:"armies"`
+}
+
+type MissionResponse struct {
+	Player  game.Player   `json:"player"`
+	Mission *game.Mission `json:"mission"`
 }
 
 type ArmyResponse struct {
 	Type  string `json:"type"`
 	Value int    `json:"value"`
 }
+
+// Type History lists every event in a game, in sequence
+type History struct {
+	ID     string       `json:"id"`
+	Events []game.Event `json:"events"`
+}
+
+type MapValidation struct {
+	Valid    bool     `json:"valid"`
+	Problems []string `json:"problems"`
+	// Warnings lists the parts of the map that are playable but unusual
+	Warnings []string `json:"warnings"`
+}
@@ -0,0 +1,56 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/daniel-salmon/risk/game"
+)
+
+// DOT writes the game's territory graph in the Graphviz DOT language
+// Territories are clustered by continent and filled with the colour of the player owning them,
+// and each link between two territories is a single undirected edge
+func DOT(w io.Writer, g *game.Game) error {
+	names := territoryNames(g)
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "graph %s {\n", quote(g.Name))
+	fmt.Fprintf(&b, "\tnode [shape=ellipse, style=filled, fontname=\"sans-serif\"];\n")
+
+	for i, continent := range continentNames(g) {
+		fmt.Fprintf(&b, "\tsubgraph %s {\n", quote("cluster_"+continent))
+		fmt.Fprintf(&b, "\t\tlabel=%s;\n", quote(fmt.Sprintf("%s (+%d)", continent, g.Continents[continent])))
+		fmt.Fprintf(&b, "\t\tcolor=%s;\n", quote(continentColors[i%len(continentColors)]))
+		for _, name := range names {
+			t := g.Territories[name]
+			if t.Continent != continent {
+				continue
+			}
+			fmt.Fprintf(&b, "\t\t%s [label=%s, fillcolor=%s];\n", quote(name), quote(fmt.Sprintf("%s\n%d", name, t.Strength())), quote(ownerColor(t.OwnedBy)))
+		}
+		fmt.Fprintf(&b, "\t}\n")
+	}
+
+	for _, name := range names {
+		for _, link := range g.Territories[name].Links {
+			if _, ok := g.Territories[link]; !ok || link < name {
+				continue
+			}
+			fmt.Fprintf(&b, "\t%s -- %s;\n", quote(name), quote(link))
+		}
+	}
+	fmt.Fprintf(&b, "}\n")
+
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// quote turns the string into a quoted DOT ID
+func quote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
@@ -0,0 +1,51 @@
+package render
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/daniel-salmon/risk/game"
+)
+
+func TestDOT(t *testing.T) {
+	g := newBoardGame(t, game.Options{})
+
+	var b bytes.Buffer
+	if err := DOT(&b, g); err != nil {
+		t.Fatal("Unexpected error exporting the graph:", err)
+	}
+	dot := b.String()
+
+	if !strings.HasPrefix(dot, `graph "Board" {`) || !strings.HasSuffix(dot, "}\n") {
+		t.Errorf("Expected an undirected graph named after the game, got: %s", dot)
+	}
+	if clusters := strings.Count(dot, "subgraph "); clusters != 6 {
+		t.Errorf("Expected a cluster for each of the 6 continents, got: %d", clusters)
+	}
+	// The classic map has 83 links, and each is one edge
+	if edges := strings.Count(dot, " -- "); edges != 83 {
+		t.Errorf("Expected 83 edges, got: %d", edges)
+	}
+
+	for _, want := range []string{
+		`subgraph "cluster_North America" {`,
+		`label="North America (+5)";`,
+		`"Alaska" [label="Alaska\n7", fillcolor="#1e88e5"];`,
+		`"Alberta" [label="Alberta\n1", fillcolor="#e53935"];`,
+		`"Alaska" -- "Kamchatka";`,
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("Expected the graph to contain %q", want)
+		}
+	}
+	if strings.Contains(dot, `"Kamchatka" -- "Alaska";`) {
+		t.Error("Expected each link to be exported once")
+	}
+}
+
+func TestQuote(t *testing.T) {
+	if q := quote("Say \"hi\"\\\n"); q != `"Say \"hi\"\\\n"` {
+		t.Errorf("Expected quotes, backslashes and newlines to be escaped, got: %s", q)
+	}
+}
@@ -0,0 +1,205 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/daniel-salmon/risk/game"
+)
+
+const (
+	width  = 1000
+	height = 600
+	margin = 60
+	radius = 16
+)
+
+// playerColors are the colours territories are filled with, indexed by the ID of the player owning them
+var playerColors = []string{"#e53935", "#1e88e5", "#43a047", "#fdd835", "#8e24aa", "#fb8c00"}
+
+// continentColors are the colours continents are outlined with, in alphabetical order of continent
+var continentColors = []string{"#6d4c41", "#00897b", "#3949ab", "#c0ca33", "#d81b60", "#546e7a"}
+
+const (
+	neutralColor  = "#9e9e9e"
+	unownedColor  = "#ffffff"
+	linkColor     = "#bdbdbd"
+	strokeColor   = "#212121"
+	continentPad  = 30
+	legendSpacing = 18
+)
+
+type point struct {
+	X float64
+	Y float64
+}
+
+// SVG writes a drawing of the game's board
+// Territories are coloured by the player owning them and labelled with their armies,
+// continents are outlined, and the links between territories are drawn as edges
+func SVG(w io.Writer, g *game.Game) error {
+	positions := layout(g)
+	names := territoryNames(g)
+	continents := continentNames(g)
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="10">`+"\n", width, height, width, height)
+	fmt.Fprintf(&b, "<title>%s</title>\n", html.EscapeString(g.Name))
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="#f5f5f5"/>`+"\n", width, height)
+
+	// Outline each continent with a box around its territories
+	b.WriteString(`<g class="continents">` + "\n")
+	for i, continent := range continents {
+		min := point{X: math.Inf(1), Y: math.Inf(1)}
+		max := point{X: math.Inf(-1), Y: math.Inf(-1)}
+		for _, name := range names {
+			if g.Territories[name].Continent != continent {
+				continue
+			}
+			p := positions[name]
+			min.X, min.Y = math.Min(min.X, p.X), math.Min(min.Y, p.Y)
+			max.X, max.Y = math.Max(max.X, p.X), math.Max(max.Y, p.Y)
+		}
+		color := continentColors[i%len(continentColors)]
+		fmt.Fprintf(&b, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" rx="8" fill="none" stroke="%s" stroke-width="2" stroke-dasharray="6 4"/>`+"\n",
+			min.X-continentPad, min.Y-continentPad, max.X-min.X+2*continentPad, max.Y-min.Y+2*continentPad, color)
+		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" fill="%s" font-weight="bold">%s (+%d)</text>`+"\n",
+			min.X-continentPad+4, min.Y-continentPad-4, color, html.EscapeString(continent), g.Continents[continent])
+	}
+	b.WriteString("</g>\n")
+
+	// Draw each link once, wrapping links that cross most of the board around its edges
+	b.WriteString(`<g class="links">` + "\n")
+	for _, name := range names {
+		for _, link := range g.Territories[name].Links {
+			if _, ok := g.Territories[link]; !ok || link < name {
+				continue
+			}
+			from, to := positions[name], positions[link]
+			if math.Abs(to.X-from.X) <= width/2 {
+				writeLine(&b, from, to)
+				continue
+			}
+			if from.X > to.X {
+				from, to = to, from
+			}
+			mid := from.Y + (to.Y-from.Y)/2
+			writeLine(&b, from, point{X: 0, Y: mid})
+			writeLine(&b, to, point{X: width, Y: mid})
+		}
+	}
+	b.WriteString("</g>\n")
+
+	b.WriteString(`<g class="territories">` + "\n")
+	for _, name := range names {
+		t := g.Territories[name]
+		p := positions[name]
+		fmt.Fprintf(&b, `<g class="territory"><circle cx="%.1f" cy="%.1f" r="%d" fill="%s" stroke="%s"/>`, p.X, p.Y, radius, ownerColor(t.OwnedBy), strokeColor)
+		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" text-anchor="middle" font-weight="bold">%d</text>`, p.X, p.Y+4, t.Strength())
+		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" text-anchor="middle">%s</text></g>`+"\n", p.X, p.Y+radius+12, html.EscapeString(name))
+	}
+	b.WriteString("</g>\n")
+
+	b.WriteString(`<g class="legend">` + "\n")
+	for i := range g.Players {
+		p := g.Players[i]
+		y := height - margin/2 - legendSpacing*(len(g.Players)-1-i)
+		fmt.Fprintf(&b, `<circle cx="%d" cy="%d" r="6" fill="%s" stroke="%s"/>`, 12, y, ownerColor(&p), strokeColor)
+		fmt.Fprintf(&b, `<text x="%d" y="%d">%s</text>`+"\n", 24, y+4, html.EscapeString(p.Name))
+	}
+	b.WriteString("</g>\n")
+	b.WriteString("</svg>\n")
+
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+func writeLine(b *bytes.Buffer, from, to point) {
+	fmt.Fprintf(b, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s" stroke-width="2"/>`+"\n", from.X, from.Y, to.X, to.Y, linkColor)
+}
+
+func ownerColor(p *game.Player) string {
+	switch {
+	case p == nil:
+		return unownedColor
+	case p.Neutral:
+		return neutralColor
+	default:
+		return playerColors[p.ID%len(playerColors)]
+	}
+}
+
+// layout positions the territories on the board
+// Maps that position their territories are scaled to fit the board, while the territories of maps that don't
+// are spaced evenly around a circle, grouped by continent
+func layout(g *game.Game) map[string]point {
+	positions := make(map[string]point)
+	if m := g.Map(); m != nil {
+		for _, t := range m.Territories {
+			if t.X != 0 || t.Y != 0 {
+				positions[t.Name] = point{X: t.X, Y: t.Y}
+			}
+		}
+	}
+
+	names := territoryNames(g)
+	if len(positions) < len(names) {
+		sort.SliceStable(names, func(i, j int) bool {
+			return g.Territories[names[i]].Continent < g.Territories[names[j]].Continent
+		})
+		for i, name := range names {
+			angle := 2 * math.Pi * float64(i) / float64(len(names))
+			positions[name] = point{X: math.Cos(angle), Y: math.Sin(angle)}
+		}
+	}
+
+	return fit(positions)
+}
+
+// fit scales the positions to fill the board inside its margins
+func fit(positions map[string]point) map[string]point {
+	min := point{X: math.Inf(1), Y: math.Inf(1)}
+	max := point{X: math.Inf(-1), Y: math.Inf(-1)}
+	for _, p := range positions {
+		min.X, min.Y = math.Min(min.X, p.X), math.Min(min.Y, p.Y)
+		max.X, max.Y = math.Max(max.X, p.X), math.Max(max.Y, p.Y)
+	}
+
+	scale := func(v, min, max, size float64) float64 {
+		if max == min {
+			return size / 2
+		}
+		return margin + (v-min)/(max-min)*(size-2*margin)
+	}
+	for name, p := range positions {
+		positions[name] = point{
+			X: scale(p.X, min.X, max.X, width),
+			Y: scale(p.Y, min.Y, max.Y, height),
+		}
+	}
+	return positions
+}
+
+// territoryNames returns the names of the game's territories in alphabetical order
+func territoryNames(g *game.Game) []string {
+	names := make([]string, 0, len(g.Territories))
+	for name := range g.Territories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// continentNames returns the names of the game's continents in alphabetical order
+func continentNames(g *game.Game) []string {
+	names := make([]string, 0, len(g.Continents))
+	for name := range g.Continents {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,120 @@
+package render
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/daniel-salmon/risk/game"
+)
+
+// newBoardGame returns a game in which player 0 owns every territory except Alaska, which player 1 owns with 7 armies
+func newBoardGame(t *testing.T, options game.Options) *game.Game {
+	players := []game.Player{
+		game.Player{ID: 0, Name: "Zero"},
+		game.Player{ID: 1, Name: "One & Only"},
+		game.Player{ID: 2, Name: "Two"},
+	}
+	g, err := game.NewGame("Board", players, options)
+	if err != nil {
+		t.Fatal("Unexpected error while building new game:", err)
+	}
+	for name, territory := range g.Territories {
+		territory.OwnedBy = &g.Players[0]
+		territory.Armies = map[game.Army]int{game.Infantry: 1, game.Cavalry: 0, game.Artillery: 0}
+		if name == "Alaska" {
+			territory.OwnedBy = &g.Players[1]
+			territory.Armies = map[game.Army]int{game.Infantry: 2, game.Cavalry: 1, game.Artillery: 0}
+		}
+	}
+	return g
+}
+
+func TestSVG(t *testing.T) {
+	g := newBoardGame(t, game.Options{})
+
+	var b bytes.Buffer
+	if err := SVG(&b, g); err != nil {
+		t.Fatal("Unexpected error rendering the board:", err)
+	}
+	svg := b.String()
+
+	// The drawing must be well-formed XML
+	decoder := xml.NewDecoder(strings.NewReader(svg))
+	elements := make(map[string]int)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal("Rendered board is not valid XML:", err)
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			elements[start.Name.Local]++
+		}
+	}
+
+	// One circle per territory plus one per player in the legend
+	if elements["circle"] != 42+3 {
+		t.Errorf("Expected 45 circles, got: %d", elements["circle"])
+	}
+	// One dashed outline per continent, plus the background
+	if elements["rect"] != 6+1 {
+		t.Errorf("Expected 7 rects, got: %d", elements["rect"])
+	}
+	// The 83 links of the classic map, with Alaska to Kamchatka wrapped around the edges of the board
+	if elements["line"] != 84 {
+		t.Errorf("Expected 84 lines, got: %d", elements["line"])
+	}
+
+	for _, want := range []string{
+		`<circle cx="60.0" cy="100.9" r="16" fill="#1e88e5" stroke="#212121"/>`,
+		`font-weight="bold">7</text>`,
+		`>Alaska</text>`,
+		`>Asia (+7)</text>`,
+		`>One &amp; Only</text>`,
+	} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("Expected the board to contain %q", want)
+		}
+	}
+}
+
+func TestSVGWithoutPositions(t *testing.T) {
+	m := &game.Map{
+		Name:       "unpositioned",
+		Continents: []game.Continent{game.Continent{Name: "Only", Bonus: 1}},
+		Territories: []game.MapTerritory{
+			game.MapTerritory{Name: "A", Continent: "Only", Links: []string{"B"}},
+			game.MapTerritory{Name: "B", Continent: "Only", Links: []string{"A", "C"}},
+			game.MapTerritory{Name: "C", Continent: "Only", Links: []string{"B"}},
+		},
+		Cards: []game.Card{
+			game.Card{Territory: "A", ArmyType: game.Infantry},
+			game.Card{Territory: "B", ArmyType: game.Cavalry},
+			game.Card{Territory: "C", ArmyType: game.Artillery},
+		},
+	}
+	game.RegisterMap(m)
+	g, err := game.NewGame("Unpositioned", []game.Player{
+		game.Player{ID: 0, Name: "Zero"},
+		game.Player{ID: 1, Name: "One"},
+		game.Player{ID: 2, Name: "Two"},
+	}, game.Options{Map: "unpositioned"})
+	if err != nil {
+		t.Fatal("Unexpected error while building new game:", err)
+	}
+
+	positions := layout(g)
+	for name, p := range positions {
+		if p.X < margin || p.X > width-margin || p.Y < margin || p.Y > height-margin {
+			t.Errorf("Expected %q to be laid out inside the margins, got: %v", name, p)
+		}
+	}
+	if positions["A"] == positions["B"] || positions["B"] == positions["C"] {
+		t.Errorf("Expected every territory to get its own position, got: %v", positions)
+	}
+}
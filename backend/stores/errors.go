@@ -0,0 +1,13 @@
+package stores
+
+import (
+	"fmt"
+)
+
+type GameNotFoundError struct {
+	ID string
+}
+
+func (e *GameNotFoundError) Error() string {
+	return fmt.Sprintf("Game %q not found", e.ID)
+}
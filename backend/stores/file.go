@@ -0,0 +1,217 @@
+package stores
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/daniel-salmon/risk/game"
+)
+
+// Type FileStore keeps the events of each game in its own file in a directory, so games survive the server restarting
+// Each file is an append-only log holding one JSON event per line
+// A crash part way through appending leaves an unfinished last line, which is ignored and then written over
+type FileStore struct {
+	mu  sync.RWMutex
+	dir string
+}
+
+// NewFileStore returns a store keeping its games in the directory, creating the directory if need be
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+func (s *FileStore) CreateGame(name string, players []game.Player, options game.Options) (*game.Game, error) {
+	g, err := game.NewGame(name, players, options)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var f *os.File
+	for {
+		id, err := newID()
+		if err != nil {
+			return nil, err
+		}
+		f, err = os.OpenFile(s.path(id), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if os.IsExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		g.ID = id
+		break
+	}
+	if err := appendEvents(f, g.Events(0)); err != nil {
+		os.Remove(f.Name())
+		return nil, err
+	}
+	return g, nil
+}
+
+func (s *FileStore) Get(id string) (*game.Game, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	events, _, err := s.read(id)
+	if err != nil {
+		return nil, err
+	}
+	return rebuild(id, events)
+}
+
+func (s *FileStore) List() ([]*game.Game, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	paths, err := filepath.Glob(filepath.Join(s.dir, "*.jsonl"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	games := []*game.Game{}
+	for _, path := range paths {
+		id := strings.TrimSuffix(filepath.Base(path), ".jsonl")
+		if !validID(id) {
+			continue
+		}
+		events, _, err := s.read(id)
+		if err != nil {
+			return nil, err
+		}
+		g, err := rebuild(id, events)
+		if err != nil {
+			return nil, err
+		}
+		games = append(games, g)
+	}
+	return games, nil
+}
+
+func (s *FileStore) Update(id string, update func(*game.Game) error) (*game.Game, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	events, size, err := s.read(id)
+	if err != nil {
+		return nil, err
+	}
+	g, err := rebuild(id, events)
+	if err != nil {
+		return nil, err
+	}
+	if err := update(g); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(s.path(id), os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	// Write over anything left behind by an append that never finished
+	if err := f.Truncate(size); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if _, err := f.Seek(size, 0); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if err := appendEvents(f, g.Events(len(events))); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func (s *FileStore) Events(id string) ([]game.Event, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	events, _, err := s.read(id)
+	return events, err
+}
+
+func (s *FileStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !validID(id) {
+		return &GameNotFoundError{ID: id}
+	}
+	err := os.Remove(s.path(id))
+	if os.IsNotExist(err) {
+		return &GameNotFoundError{ID: id}
+	}
+	return err
+}
+
+func (s *FileStore) Close() error {
+	return nil
+}
+
+func (s *FileStore) path(id string) string {
+	return filepath.Join(s.dir, id+".jsonl")
+}
+
+// read returns the game's events, along with the size of the file up to the end of the last complete event
+func (s *FileStore) read(id string) ([]game.Event, int64, error) {
+	if !validID(id) {
+		return nil, 0, &GameNotFoundError{ID: id}
+	}
+	data, err := ioutil.ReadFile(s.path(id))
+	if os.IsNotExist(err) {
+		return nil, 0, &GameNotFoundError{ID: id}
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Only lines ending in a newline were written in full
+	complete := bytes.LastIndexByte(data, '\n') + 1
+	events := []game.Event{}
+	scanner := bufio.NewScanner(bytes.NewReader(data[:complete]))
+	scanner.Buffer(nil, len(data)+1)
+	for scanner.Scan() {
+		var e game.Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, 0, err
+		}
+		events = append(events, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, 0, err
+	}
+	return events, int64(complete), nil
+}
+
+// appendEvents writes the events to the end of the file, one per line, and closes it
+// The events are on disk before appendEvents returns
+func appendEvents(f *os.File, events []game.Event) error {
+	var buf bytes.Buffer
+	for _, e := range events {
+		data, err := json.Marshal(e)
+		if err != nil {
+			f.Close()
+			return err
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,113 @@
+package stores
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/daniel-salmon/risk/game"
+)
+
+// Type MemoryStore holds the events of every game being played in memory, so games are lost when the server stops
+type MemoryStore struct {
+	mu     sync.RWMutex
+	events map[string][]game.Event
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{events: make(map[string][]game.Event)}
+}
+
+func (s *MemoryStore) CreateGame(name string, players []game.Player, options game.Options) (*game.Game, error) {
+	g, err := game.NewGame(name, players, options)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for {
+		id, err := newID()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := s.events[id]; !ok {
+			g.ID = id
+			break
+		}
+	}
+	s.events[g.ID] = g.Events(0)
+	return g, nil
+}
+
+func (s *MemoryStore) Get(id string) (*game.Game, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	events, ok := s.events[id]
+	if !ok {
+		return nil, &GameNotFoundError{ID: id}
+	}
+	return rebuild(id, events)
+}
+
+func (s *MemoryStore) List() ([]*game.Game, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	ids := make([]string, 0, len(s.events))
+	for id := range s.events {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	games := make([]*game.Game, 0, len(ids))
+	for _, id := range ids {
+		g, err := rebuild(id, s.events[id])
+		if err != nil {
+			return nil, err
+		}
+		games = append(games, g)
+	}
+	return games, nil
+}
+
+func (s *MemoryStore) Update(id string, update func(*game.Game) error) (*game.Game, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	events, ok := s.events[id]
+	if !ok {
+		return nil, &GameNotFoundError{ID: id}
+	}
+
+	g, err := rebuild(id, events)
+	if err != nil {
+		return nil, err
+	}
+	if err := update(g); err != nil {
+		return nil, err
+	}
+	s.events[id] = append(events[:len(events):len(events)], g.Events(len(events))...)
+	return g, nil
+}
+
+func (s *MemoryStore) Events(id string) ([]game.Event, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	events, ok := s.events[id]
+	if !ok {
+		return nil, &GameNotFoundError{ID: id}
+	}
+	return append([]game.Event{}, events...), nil
+}
+
+func (s *MemoryStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.events[id]; !ok {
+		return &GameNotFoundError{ID: id}
+	}
+	delete(s.events, id)
+	return nil
+}
+
+func (s *MemoryStore) Close() error {
+	return nil
+}
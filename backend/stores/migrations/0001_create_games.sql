@@ -0,0 +1,44 @@
+CREATE TABLE games (
+	id TEXT PRIMARY KEY,
+	name TEXT NOT NULL,
+	phase TEXT NOT NULL,
+	current_player INTEGER NOT NULL,
+	turn_number INTEGER NOT NULL,
+	-- state holds the rest of the game, such as its options, reserves and winners, as JSON
+	state TEXT NOT NULL,
+	created_at TEXT NOT NULL,
+	updated_at TEXT NOT NULL
+);
+
+CREATE TABLE players (
+	game_id TEXT NOT NULL REFERENCES games (id) ON DELETE CASCADE,
+	id INTEGER NOT NULL,
+	name TEXT NOT NULL,
+	neutral INTEGER NOT NULL,
+	team_id INTEGER NOT NULL,
+	eliminated INTEGER NOT NULL,
+	PRIMARY KEY (game_id, id)
+);
+
+CREATE TABLE territories (
+	game_id TEXT NOT NULL REFERENCES games (id) ON DELETE CASCADE,
+	name TEXT NOT NULL,
+	continent TEXT NOT NULL,
+	-- links holds the names of the linked territories as a JSON array
+	links TEXT NOT NULL,
+	owner_id INTEGER,
+	infantry INTEGER NOT NULL,
+	cavalry INTEGER NOT NULL,
+	artillery INTEGER NOT NULL,
+	PRIMARY KEY (game_id, name)
+);
+
+CREATE TABLE cards (
+	game_id TEXT NOT NULL REFERENCES games (id) ON DELETE CASCADE,
+	-- pile is "draw", "discard" or "hand", and position is the card's place in its pile or hand
+	pile TEXT NOT NULL,
+	owner_id INTEGER,
+	position INTEGER NOT NULL,
+	territory TEXT NOT NULL,
+	army_type INTEGER NOT NULL
+);
@@ -0,0 +1,4 @@
+-- Find the games a player is in by name
+CREATE INDEX players_name ON players (name, eliminated);
+
+CREATE INDEX cards_game ON cards (game_id);
@@ -0,0 +1,11 @@
+-- events holds every game's event stream, from which the game is rebuilt
+-- The other tables are kept up to date with the latest state of each game so games can be queried
+CREATE TABLE events (
+	game_id TEXT NOT NULL REFERENCES games (id) ON DELETE CASCADE,
+	sequence INTEGER NOT NULL,
+	time TEXT NOT NULL,
+	type TEXT NOT NULL,
+	-- action holds the event's action as JSON
+	action TEXT NOT NULL,
+	PRIMARY KEY (game_id, sequence)
+);
@@ -0,0 +1,410 @@
+package stores
+
+import (
+	"database/sql"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/daniel-salmon/risk/game"
+
+	// Register the pure Go SQLite driver, which builds without cgo
+	_ "modernc.org/sqlite"
+)
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// columns are the fields of a game kept in their own columns or tables, rather than in the games table's state
+var columns = []string{"id", "name", "phase", "currentPlayer", "turnNumber", "players", "territories", "cards"}
+
+// Type SQLiteStore keeps the events of every game in a SQLite database
+// The latest state of each game is kept alongside its events, with players, territories and cards each in their own table,
+// so games can be queried across, while the rest of each game is kept as JSON
+type SQLiteStore struct {
+	mu sync.RWMutex
+	db *sql.DB
+}
+
+// NewSQLiteStore opens the SQLite database at the path, creating it if need be, and migrates it to the latest schema
+func NewSQLiteStore(path string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite", fmt.Sprintf("file:%s?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)", path))
+	if err != nil {
+		return nil, err
+	}
+	// SQLite only allows one writer at a time
+	db.SetMaxOpenConns(1)
+
+	s := &SQLiteStore{db: db}
+	if err := s.migrate(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// migrate applies every migration newer than the database's schema version, in order
+// Each migration is named after its version, e.g. 0001_create_games.sql is version 1
+func (s *SQLiteStore) migrate() error {
+	if _, err := s.db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER PRIMARY KEY, applied_at TEXT NOT NULL)`); err != nil {
+		return err
+	}
+	current, err := s.SchemaVersion()
+	if err != nil {
+		return err
+	}
+
+	entries, err := migrations.ReadDir("migrations")
+	if err != nil {
+		return err
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	for _, entry := range entries {
+		version, err := strconv.Atoi(strings.SplitN(entry.Name(), "_", 2)[0])
+		if err != nil {
+			return fmt.Errorf("migration %s is not named after its version", entry.Name())
+		}
+		if version <= current {
+			continue
+		}
+		migration, err := migrations.ReadFile(path.Join("migrations", entry.Name()))
+		if err != nil {
+			return err
+		}
+
+		tx, err := s.db.Begin()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(string(migration)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %s: %w", entry.Name(), err)
+		}
+		if _, err := tx.Exec(`INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?)`, version, now()); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SchemaVersion returns the version of the latest migration applied to the database
+func (s *SQLiteStore) SchemaVersion() (int, error) {
+	var version int
+	err := s.db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&version)
+	return version, err
+}
+
+func (s *SQLiteStore) CreateGame(name string, players []game.Player, options game.Options) (*game.Game, error) {
+	g, err := game.NewGame(name, players, options)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	for {
+		id, err := newID()
+		if err != nil {
+			return nil, err
+		}
+		var exists int
+		if err := tx.QueryRow(`SELECT COUNT(*) FROM games WHERE id = ?`, id).Scan(&exists); err != nil {
+			return nil, err
+		}
+		if exists == 0 {
+			g.ID = id
+			break
+		}
+	}
+	if _, err := tx.Exec(`INSERT INTO games (id, name, phase, current_player, turn_number, state, created_at, updated_at) VALUES (?, '', '', 0, 0, '{}', ?, ?)`, g.ID, now(), now()); err != nil {
+		return nil, err
+	}
+	if err := insertEvents(tx, g.ID, g.Events(0)); err != nil {
+		return nil, err
+	}
+	if err := write(tx, g); err != nil {
+		return nil, err
+	}
+	return g, tx.Commit()
+}
+
+func (s *SQLiteStore) Get(id string) (*game.Game, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	return read(tx, id)
+}
+
+func (s *SQLiteStore) List() ([]*game.Game, error) {
+	return s.query(`SELECT id FROM games ORDER BY id`)
+}
+
+// ActiveGames returns the games that aren't over in which a player of the given name hasn't been eliminated
+func (s *SQLiteStore) ActiveGames(playerName string) ([]*game.Game, error) {
+	return s.query(`
+		SELECT games.id FROM games
+		JOIN players ON players.game_id = games.id
+		WHERE players.name = ? AND players.eliminated = 0 AND games.phase != ?
+		ORDER BY games.id`, playerName, string(game.GameOverPhase))
+}
+
+// query returns the games whose IDs the query selects
+func (s *SQLiteStore) query(query string, args ...interface{}) ([]*game.Game, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	games := []*game.Game{}
+	for _, id := range ids {
+		g, err := read(tx, id)
+		if err != nil {
+			return nil, err
+		}
+		games = append(games, g)
+	}
+	return games, nil
+}
+
+func (s *SQLiteStore) Update(id string, update func(*game.Game) error) (*game.Game, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	g, err := read(tx, id)
+	if err != nil {
+		return nil, err
+	}
+	sequence := g.Sequence
+	if err := update(g); err != nil {
+		return nil, err
+	}
+	if err := insertEvents(tx, id, g.Events(sequence)); err != nil {
+		return nil, err
+	}
+	if err := write(tx, g); err != nil {
+		return nil, err
+	}
+	return g, tx.Commit()
+}
+
+func (s *SQLiteStore) Events(id string) ([]game.Event, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	return readEvents(tx, id)
+}
+
+func (s *SQLiteStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result, err := s.db.Exec(`DELETE FROM games WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return &GameNotFoundError{ID: id}
+	}
+	return nil
+}
+
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+// write saves the game over its existing rows
+func write(tx *sql.Tx, g *game.Game) error {
+	data, err := json.Marshal(g)
+	if err != nil {
+		return err
+	}
+	state := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+	for _, column := range columns {
+		delete(state, column)
+	}
+	stateData, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`UPDATE games SET name = ?, phase = ?, current_player = ?, turn_number = ?, state = ?, updated_at = ? WHERE id = ?`,
+		g.Name, string(g.Phase), g.CurrentPlayer, g.TurnNumber, string(stateData), now(), g.ID); err != nil {
+		return err
+	}
+	for _, table := range []string{"players", "territories", "cards"} {
+		if _, err := tx.Exec(`DELETE FROM `+table+` WHERE game_id = ?`, g.ID); err != nil {
+			return err
+		}
+	}
+
+	eliminated := make(map[int]bool)
+	for _, e := range g.Eliminations {
+		eliminated[e.PlayerID] = true
+	}
+	for _, p := range g.Players {
+		if _, err := tx.Exec(`INSERT INTO players (game_id, id, name, neutral, team_id, eliminated) VALUES (?, ?, ?, ?, ?, ?)`,
+			g.ID, p.ID, p.Name, p.Neutral, p.TeamID, eliminated[p.ID]); err != nil {
+			return err
+		}
+	}
+
+	for _, t := range g.Territories {
+		links, err := json.Marshal(t.Links)
+		if err != nil {
+			return err
+		}
+		var owner interface{}
+		if t.OwnedBy != nil {
+			owner = t.OwnedBy.ID
+		}
+		if _, err := tx.Exec(`INSERT INTO territories (game_id, name, continent, links, owner_id, infantry, cavalry, artillery) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			g.ID, t.Name, t.Continent, string(links), owner, t.Armies[game.Infantry], t.Armies[game.Cavalry], t.Armies[game.Artillery]); err != nil {
+			return err
+		}
+	}
+
+	insertCards := func(pile string, owner interface{}, cards []game.Card) error {
+		for i, card := range cards {
+			if _, err := tx.Exec(`INSERT INTO cards (game_id, pile, owner_id, position, territory, army_type) VALUES (?, ?, ?, ?, ?, ?)`,
+				g.ID, pile, owner, i, card.Territory, int(card.ArmyType)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := insertCards("draw", nil, g.Cards.DrawPile); err != nil {
+		return err
+	}
+	if err := insertCards("discard", nil, g.Cards.DiscardPile); err != nil {
+		return err
+	}
+	for playerID, hand := range g.Cards.OwnedBy {
+		if err := insertCards("hand", playerID, hand); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// read rebuilds the game from its events
+func read(tx *sql.Tx, id string) (*game.Game, error) {
+	events, err := readEvents(tx, id)
+	if err != nil {
+		return nil, err
+	}
+	return rebuild(id, events)
+}
+
+// readEvents returns the game's events in sequence
+func readEvents(tx *sql.Tx, id string) ([]game.Event, error) {
+	rows, err := tx.Query(`SELECT sequence, time, type, action FROM events WHERE game_id = ? ORDER BY sequence`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []game.Event{}
+	for rows.Next() {
+		var raw struct {
+			Sequence int             `json:"sequence"`
+			Time     string          `json:"time"`
+			Type     string          `json:"type"`
+			Action   json.RawMessage `json:"action"`
+		}
+		var action string
+		if err := rows.Scan(&raw.Sequence, &raw.Time, &raw.Type, &action); err != nil {
+			return nil, err
+		}
+		raw.Action = json.RawMessage(action)
+		data, err := json.Marshal(raw)
+		if err != nil {
+			return nil, err
+		}
+		var e game.Event
+		if err := json.Unmarshal(data, &e); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, &GameNotFoundError{ID: id}
+	}
+	return events, nil
+}
+
+// insertEvents adds the events to the game's event stream
+func insertEvents(tx *sql.Tx, id string, events []game.Event) error {
+	for _, e := range events {
+		action, err := json.Marshal(e.Action)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`INSERT INTO events (game_id, sequence, time, type, action) VALUES (?, ?, ?, ?, ?)`,
+			id, e.Sequence, e.Time.Format(time.RFC3339Nano), string(e.Action.Type()), string(action)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339Nano)
+}
@@ -0,0 +1,124 @@
+package stores
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/daniel-salmon/risk/game"
+)
+
+// newSQLitePath returns the path of a database file in a temporary directory
+func newSQLitePath(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "risk-sqlite")
+	if err != nil {
+		t.Fatal("Unexpected error creating a temporary directory:", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return filepath.Join(dir, "games.db")
+}
+
+func TestSQLiteMigrations(t *testing.T) {
+	path := newSQLitePath(t)
+
+	s, err := NewSQLiteStore(path)
+	if err != nil {
+		t.Fatal("Unexpected error building the SQLite store:", err)
+	}
+	if version, err := s.SchemaVersion(); err != nil || version != 3 {
+		t.Errorf("Expected the schema to be migrated to version 3, got: %d (%v)", version, err)
+	}
+	s.Close()
+
+	// Reopening an up to date database applies nothing twice
+	s, err = NewSQLiteStore(path)
+	if err != nil {
+		t.Fatal("Unexpected error reopening the SQLite store:", err)
+	}
+	defer s.Close()
+	var applied int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM schema_migrations`).Scan(&applied); err != nil || applied != 3 {
+		t.Errorf("Expected each migration to be applied once, got: %d (%v)", applied, err)
+	}
+}
+
+func TestSQLiteStoreRestart(t *testing.T) {
+	path := newSQLitePath(t)
+
+	s, err := NewSQLiteStore(path)
+	if err != nil {
+		t.Fatal("Unexpected error building the SQLite store:", err)
+	}
+	g, err := s.CreateGame("Weekend", players, game.Options{Mode: game.SecretMission})
+	if err != nil {
+		t.Fatal("Unexpected error creating a game:", err)
+	}
+	g, err = s.Update(g.ID, func(g *game.Game) error {
+		return g.Place(0, ownedBy(g, 0))
+	})
+	if err != nil {
+		t.Fatal("Unexpected error updating the game:", err)
+	}
+	s.Close()
+
+	// A new store over the same database picks up where the old one left off
+	s, err = NewSQLiteStore(path)
+	if err != nil {
+		t.Fatal("Unexpected error reopening the SQLite store:", err)
+	}
+	defer s.Close()
+	restored, err := s.Get(g.ID)
+	if err != nil {
+		t.Fatal("Unexpected error getting the game after a restart:", err)
+	}
+	if restored.Name != "Weekend" || restored.CurrentPlayer != 1 || len(restored.Missions) != 3 || len(restored.Cards.DrawPile) != len(g.Cards.DrawPile) {
+		t.Errorf("Expected the game to be restored in full, got: %v", restored)
+	}
+	for name, territory := range g.Territories {
+		got := restored.Territories[name]
+		if got.OwnedBy != &restored.Players[territory.OwnedBy.ID] || got.Strength() != territory.Strength() {
+			t.Errorf("Expected %s to be restored, got owner %v and strength %d", name, got.OwnedBy, got.Strength())
+		}
+	}
+	for i, card := range g.Cards.DrawPile {
+		if restored.Cards.DrawPile[i] != card {
+			t.Errorf("Expected the draw pile to keep its order, got %v at %d, want %v", restored.Cards.DrawPile[i], i, card)
+		}
+	}
+}
+
+func TestActiveGames(t *testing.T) {
+	s, err := NewSQLiteStore(newSQLitePath(t))
+	if err != nil {
+		t.Fatal("Unexpected error building the SQLite store:", err)
+	}
+	defer s.Close()
+
+	others := []game.Player{
+		game.Player{ID: 0, Name: "Three"},
+		game.Player{ID: 1, Name: "Four"},
+		game.Player{ID: 2, Name: "Five"},
+	}
+	playing, _ := s.CreateGame("Playing", players, game.Options{})
+	s.CreateGame("Elsewhere", others, game.Options{})
+	// Stand in for games played to an elimination and to the end
+	eliminated, _ := s.CreateGame("Eliminated", players, game.Options{})
+	s.db.Exec(`UPDATE players SET eliminated = 1 WHERE game_id = ? AND id = 1`, eliminated.ID)
+	over, _ := s.CreateGame("Over", players, game.Options{})
+	s.db.Exec(`UPDATE games SET phase = ? WHERE id = ?`, string(game.GameOverPhase), over.ID)
+
+	games, err := s.ActiveGames("One")
+	if err != nil {
+		t.Fatal("Unexpected error finding active games:", err)
+	}
+	if len(games) != 1 || games[0].ID != playing.ID {
+		t.Errorf("Expected only the game still being played, got: %v", games)
+	}
+	if games, _ := s.ActiveGames("Zero"); len(games) != 2 {
+		t.Errorf("Expected 2 active games for a player who is still in, got: %d", len(games))
+	}
+	if games, _ := s.ActiveGames("Nobody"); len(games) != 0 {
+		t.Errorf("Expected no active games for an unknown player, got: %d", len(games))
+	}
+}
@@ -1,26 +1,60 @@
 package stores
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+
 	"github.com/daniel-salmon/risk/game"
 )
 
-type Store struct {
-	game *game.Game
+// Type Store holds every game being played, keyed by a generated ID
+// Stores persist each game's events and rebuild the game by replaying them,
+// so only changes made through the game's actions are kept
+// Stores are safe for use by concurrent HTTP handlers, and hand out copies of their games
+// so that changes are only stored through Update
+type Store interface {
+	// CreateGame starts a new game and stores it under a newly generated ID
+	CreateGame(name string, players []game.Player, options game.Options) (*game.Game, error)
+	// Get returns the game with the given ID
+	Get(id string) (*game.Game, error)
+	// List returns every game in the store, ordered by ID
+	List() ([]*game.Game, error)
+	// Update applies the update to the game with the given ID, and returns the updated game
+	// No other changes can be made to the game while the update runs,
+	// and the game is left untouched if the update returns an error
+	Update(id string, update func(*game.Game) error) (*game.Game, error)
+	// Events returns every event of the game with the given ID, in sequence
+	Events(id string) ([]game.Event, error)
+	// Delete removes the game with the given ID
+	Delete(id string) error
+	Close() error
 }
 
-func NewStore() (*Store, error) {
-	return &Store{}, nil
+// newID generates a random game ID
+func newID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
 }
 
-func (s *Store) CreateGame(name string, players []game.Player) (*game.Game, error) {
-	g, err := game.NewGame(name, players)
+// rebuild replays the events of the game with the given ID
+func rebuild(id string, events []game.Event) (*game.Game, error) {
+	g, err := game.Replay(events)
 	if err != nil {
 		return nil, err
 	}
-	s.game = g
-	return s.game, nil
+	g.ID = id
+	return g, nil
 }
 
-func (s *Store) Close() {
-	return
+// validID reports whether the ID could have been generated by newID
+// This keeps IDs from the outside world from escaping a file store's directory
+func validID(id string) bool {
+	if len(id) != 16 {
+		return false
+	}
+	_, err := hex.DecodeString(id)
+	return err == nil
 }
@@ -0,0 +1,227 @@
+package stores
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/daniel-salmon/risk/game"
+)
+
+var players = []game.Player{
+	game.Player{ID: 0, Name: "Zero"},
+	game.Player{ID: 1, Name: "One"},
+	game.Player{ID: 2, Name: "Two"},
+}
+
+// newStores returns one of each kind of store, keyed by kind
+func newStores(t *testing.T) map[string]Store {
+	dir, err := ioutil.TempDir("", "risk-stores")
+	if err != nil {
+		t.Fatal("Unexpected error creating a temporary directory:", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	file, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatal("Unexpected error building the file store:", err)
+	}
+	sqlite, err := NewSQLiteStore(filepath.Join(dir, "games.db"))
+	if err != nil {
+		t.Fatal("Unexpected error building the SQLite store:", err)
+	}
+	return map[string]Store{
+		"Memory": NewMemoryStore(),
+		"File":   file,
+		"SQLite": sqlite,
+	}
+}
+
+func TestStores(t *testing.T) {
+	for kind, s := range newStores(t) {
+		t.Run(kind, func(t *testing.T) {
+			testStore(t, s)
+		})
+	}
+}
+
+func TestConcurrentUpdates(t *testing.T) {
+	for kind, s := range newStores(t) {
+		t.Run(kind, func(t *testing.T) {
+			testConcurrentUpdates(t, s)
+		})
+	}
+}
+
+func testStore(t *testing.T, s Store) {
+	defer s.Close()
+
+	first, err := s.CreateGame("First", players, game.Options{})
+	if err != nil {
+		t.Fatal("Unexpected error creating a game:", err)
+	}
+	second, err := s.CreateGame("Second", players, game.Options{})
+	if err != nil {
+		t.Fatal("Unexpected error creating a game:", err)
+	}
+	if first.ID == "" || first.ID == second.ID {
+		t.Fatalf("Expected each game to get its own ID, got: %q and %q", first.ID, second.ID)
+	}
+
+	g, err := s.Get(first.ID)
+	if err != nil {
+		t.Fatal("Unexpected error getting a game:", err)
+	}
+	if g.Name != "First" {
+		t.Errorf("Expected to get the first game, got: %q", g.Name)
+	}
+	games, err := s.List()
+	if err != nil {
+		t.Fatal("Unexpected error listing games:", err)
+	}
+	if len(games) != 2 {
+		t.Errorf("Expected 2 games, got: %d", len(games))
+	}
+
+	// Changing a game outside of Update doesn't change the stored game
+	g.Place(0, ownedBy(g, 0))
+	if g, _ := s.Get(first.ID); g.CurrentPlayer != 0 {
+		t.Errorf("Expected the stored game to be unchanged, got player %d to place", g.CurrentPlayer)
+	}
+
+	updated, err := s.Update(first.ID, func(g *game.Game) error {
+		return g.Place(0, ownedBy(g, 0))
+	})
+	if err != nil {
+		t.Fatal("Unexpected error updating a game:", err)
+	}
+	if g, _ := s.Get(first.ID); updated.CurrentPlayer != 1 || g.CurrentPlayer != 1 || g.ID != first.ID {
+		t.Errorf("Expected the game to be updated, got player %d to place", g.CurrentPlayer)
+	}
+
+	// A failed update leaves the game alone
+	failure := errors.New("failed")
+	if _, err := s.Update(first.ID, func(g *game.Game) error {
+		g.Place(1, ownedBy(g, 1))
+		return failure
+	}); err != failure {
+		t.Errorf("Expected the update's error, got: %v", err)
+	}
+	if g, _ := s.Get(first.ID); g.CurrentPlayer != 1 {
+		t.Errorf("Expected a failed update to leave the game alone, got player %d to place", g.CurrentPlayer)
+	}
+
+	// The store keeps the events the game was rebuilt from
+	events, err := s.Events(first.ID)
+	if err != nil {
+		t.Fatal("Unexpected error getting a game's events:", err)
+	}
+	if len(events) != 2 || events[0].Action.Type() != game.CreatedEvent || events[1].Action.Type() != game.PlacedEvent {
+		t.Errorf("Expected the game to be created and an army placed, got: %v", events)
+	}
+
+	if err := s.Delete(first.ID); err != nil {
+		t.Fatal("Unexpected error deleting a game:", err)
+	}
+	var notFound *GameNotFoundError
+	if _, err := s.Get(first.ID); !errors.As(err, &notFound) {
+		t.Errorf("Expected a GameNotFoundError getting a deleted game, got: %v", err)
+	}
+	if _, err := s.Update(first.ID, func(*game.Game) error { return nil }); !errors.As(err, &notFound) {
+		t.Errorf("Expected a GameNotFoundError updating a deleted game, got: %v", err)
+	}
+	if err := s.Delete(first.ID); !errors.As(err, &notFound) {
+		t.Errorf("Expected a GameNotFoundError deleting a deleted game, got: %v", err)
+	}
+	if _, err := s.Events(first.ID); !errors.As(err, &notFound) {
+		t.Errorf("Expected a GameNotFoundError getting the events of a deleted game, got: %v", err)
+	}
+}
+
+func testConcurrentUpdates(t *testing.T, s Store) {
+	g, err := s.CreateGame("Concurrent", players, game.Options{})
+	if err != nil {
+		t.Fatal("Unexpected error creating a game:", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.Update(g.ID, func(g *game.Game) error {
+				return g.Place(g.CurrentPlayer, ownedBy(g, g.CurrentPlayer))
+			})
+		}()
+	}
+	wg.Wait()
+
+	if g, _ := s.Get(g.ID); g.Sequence != 1+20 {
+		t.Errorf("Expected every update to be applied, got %d events", g.Sequence)
+	}
+}
+
+func TestFileStoreRestart(t *testing.T) {
+	dir, err := ioutil.TempDir("", "risk-stores")
+	if err != nil {
+		t.Fatal("Unexpected error creating a temporary directory:", err)
+	}
+	defer os.RemoveAll(dir)
+
+	s, _ := NewFileStore(dir)
+	g, err := s.CreateGame("Weekend", players, game.Options{})
+	if err != nil {
+		t.Fatal("Unexpected error creating a game:", err)
+	}
+	s.Close()
+
+	// A new store over the same directory picks up where the old one left off
+	s, _ = NewFileStore(dir)
+	restored, err := s.Get(g.ID)
+	if err != nil {
+		t.Fatal("Unexpected error getting the game after a restart:", err)
+	}
+	if restored.Name != "Weekend" || len(restored.Territories) != 42 || restored.Territories["Alaska"].OwnedBy != &restored.Players[restored.Territories["Alaska"].OwnedBy.ID] {
+		t.Errorf("Expected the game to be restored in full, got: %v", restored)
+	}
+
+	// Each game has a single log of its events
+	paths, _ := filepath.Glob(filepath.Join(dir, "*"))
+	if len(paths) != 1 || paths[0] != filepath.Join(dir, g.ID+".jsonl") {
+		t.Errorf("Expected a single event log in the directory, got: %v", paths)
+	}
+
+	// An event cut off by a crash is ignored, and written over by the next update
+	log, _ := os.OpenFile(paths[0], os.O_WRONLY|os.O_APPEND, 0644)
+	log.WriteString(`{"sequence":2,"ti`)
+	log.Close()
+	if _, err := s.Get(g.ID); err != nil {
+		t.Fatal("Unexpected error getting a game with an unfinished event:", err)
+	}
+	if _, err := s.Update(g.ID, func(g *game.Game) error {
+		return g.Place(0, ownedBy(g, 0))
+	}); err != nil {
+		t.Fatal("Unexpected error updating a game with an unfinished event:", err)
+	}
+	if events, err := s.Events(g.ID); err != nil || len(events) != 2 {
+		t.Errorf("Expected the unfinished event to be replaced, got %d events (%v)", len(events), err)
+	}
+
+	var notFound *GameNotFoundError
+	if _, err := s.Get("../" + g.ID); !errors.As(err, &notFound) {
+		t.Errorf("Expected a GameNotFoundError for an ID outside the directory, got: %v", err)
+	}
+}
+
+// ownedBy returns the name of a territory owned by the player
+func ownedBy(g *game.Game, playerID int) string {
+	for name, t := range g.Territories {
+		if t.OwnedBy != nil && t.OwnedBy.ID == playerID {
+			return name
+		}
+	}
+	return ""
+}